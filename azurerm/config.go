@@ -131,6 +131,7 @@ type ArmClient struct {
 	appInsightsAPIKeyClient appinsights.APIKeysClient
 
 	// Authentication
+	permissionsClient       authorization.PermissionsClient
 	roleAssignmentsClient   authorization.RoleAssignmentsClient
 	roleDefinitionsClient   authorization.RoleDefinitionsClient
 	applicationsClient      graphrbac.ApplicationsClient
@@ -218,6 +219,7 @@ type ArmClient struct {
 	keyVaultManagementClient keyVault.BaseClient
 
 	// Log Analytics
+	dataSourcesClient    operationalinsights.DataSourcesClient
 	linkedServicesClient operationalinsights.LinkedServicesClient
 	workspacesClient     operationalinsights.WorkspacesClient
 
@@ -534,6 +536,10 @@ func (c *ArmClient) registerAutomationClients(endpoint, subscriptionId string, a
 }
 
 func (c *ArmClient) registerAuthentication(endpoint, graphEndpoint, subscriptionId, tenantId string, auth, graphAuth autorest.Authorizer) {
+	permissionsClient := authorization.NewPermissionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&permissionsClient.Client, auth)
+	c.permissionsClient = permissionsClient
+
 	assignmentsClient := authorization.NewRoleAssignmentsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&assignmentsClient.Client, auth)
 	c.roleAssignmentsClient = assignmentsClient
@@ -1012,6 +1018,10 @@ func (c *ArmClient) registerOperationalInsightsClients(endpoint, subscriptionId
 	lsClient := operationalinsights.NewLinkedServicesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&lsClient.Client, auth)
 	c.linkedServicesClient = lsClient
+
+	dataSourcesClient := operationalinsights.NewDataSourcesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&dataSourcesClient.Client, auth)
+	c.dataSourcesClient = dataSourcesClient
 }
 
 func (c *ArmClient) registerRecoveryServiceClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -1,6 +1,13 @@
 package azurerm
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+)
 
 func TestClientRequestID(t *testing.T) {
 	first := clientRequestID()
@@ -14,3 +21,77 @@ func TestClientRequestID(t *testing.T) {
 		t.Fatal("subsequent request ID not the same as the first")
 	}
 }
+
+func TestOperationalInsightsClientsUseEnvironmentEndpoint(t *testing.T) {
+	environments := []az.Environment{
+		az.ChinaCloud,
+		az.GermanCloud,
+		az.PublicCloud,
+		az.USGovernmentCloud,
+	}
+
+	for _, env := range environments {
+		t.Logf("[DEBUG] Testing %q", env.Name)
+
+		client := ArmClient{
+			environment: env,
+		}
+		client.registerOperationalInsightsClients(env.ResourceManagerEndpoint, "00000000-0000-0000-0000-000000000000", autorest.NullAuthorizer{})
+
+		if client.workspacesClient.BaseURI != env.ResourceManagerEndpoint {
+			t.Fatalf("Expected the Workspaces Client to use %q but got %q", env.ResourceManagerEndpoint, client.workspacesClient.BaseURI)
+		}
+
+		if client.linkedServicesClient.BaseURI != env.ResourceManagerEndpoint {
+			t.Fatalf("Expected the Linked Services Client to use %q but got %q", env.ResourceManagerEndpoint, client.linkedServicesClient.BaseURI)
+		}
+
+		if client.solutionsClient.BaseURI != env.ResourceManagerEndpoint {
+			t.Fatalf("Expected the Solutions Client to use %q but got %q", env.ResourceManagerEndpoint, client.solutionsClient.BaseURI)
+		}
+	}
+}
+
+type testAuthorizer struct{}
+
+func (a *testAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return autorest.WithNothing()
+}
+
+func TestOperationalInsightsClientsShareConfiguration(t *testing.T) {
+	auth := &testAuthorizer{}
+	client := ArmClient{
+		skipProviderRegistration: true,
+	}
+	client.registerOperationalInsightsClients("https://management.azure.com/", "00000000-0000-0000-0000-000000000000", auth)
+
+	clients := map[string]autorest.Client{
+		"Workspaces":      client.workspacesClient.Client,
+		"Data Sources":    client.dataSourcesClient.Client,
+		"Linked Services": client.linkedServicesClient.Client,
+		"Solutions":       client.solutionsClient.Client,
+	}
+
+	for name, c := range clients {
+		if c.Authorizer != auth {
+			t.Fatalf("Expected the %s Client to use the configured Authorizer", name)
+		}
+
+		if c.PollingDuration != 60*time.Minute {
+			t.Fatalf("Expected the %s Client to have a Polling Duration of 60m but got %s", name, c.PollingDuration)
+		}
+
+		// the Provider doesn't override the Polling Delay, so this should be the SDK default for every Client
+		if c.PollingDelay != autorest.DefaultPollingDelay {
+			t.Fatalf("Expected the %s Client to have a Polling Delay of %s but got %s", name, autorest.DefaultPollingDelay, c.PollingDelay)
+		}
+
+		if !c.SkipResourceProviderRegistration {
+			t.Fatalf("Expected the %s Client to skip Resource Provider Registration", name)
+		}
+
+		if !strings.Contains(c.UserAgent, "terraform-provider-azurerm") {
+			t.Fatalf("Expected the %s Client's User Agent to contain `terraform-provider-azurerm` but got %q", name, c.UserAgent)
+		}
+	}
+}
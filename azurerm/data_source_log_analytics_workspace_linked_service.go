@@ -0,0 +1,98 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedService() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmLogAnalyticsWorkspaceLinkedServiceRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAzureRmLogAnalyticsWorkspaceName,
+			},
+
+			"linked_service_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "automation",
+				ValidateFunc: validateLogAnalyticsWorkspaceLinkedServiceName,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"resource_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"linked_service_properties": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsForDataSourceSchema(),
+		},
+	}
+}
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedServiceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).linkedServicesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resGroup := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	lsName := d.Get("linked_service_name").(string)
+
+	resp, err := client.Get(ctx, resGroup, workspaceName, lsName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: Linked Service %q (Workspace %q / Resource Group %q) was not found", lsName, workspaceName, resGroup)
+		}
+
+		return fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+	}
+
+	// the API can return an empty 200 rather than a 404 when the Linked Service doesn't exist
+	if resp.ID == nil || *resp.ID == "" {
+		return fmt.Errorf("Error: Linked Service %q (Workspace %q / Resource Group %q) was not found", lsName, workspaceName, resGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	d.Set("name", resp.Name)
+	d.Set("resource_group_name", resGroup)
+	d.Set("workspace_name", workspaceName)
+	d.Set("linked_service_name", lsName)
+
+	if props := resp.LinkedServiceProperties; props != nil {
+		d.Set("resource_id", props.ResourceID)
+	}
+
+	if err := d.Set("linked_service_properties", flattenLogAnalyticsWorkspaceLinkedServiceProperties(resp.LinkedServiceProperties)); err != nil {
+		return fmt.Errorf("Error setting `linked_service_properties`: %+v", err)
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+)
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedServiceImports() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmLogAnalyticsWorkspaceLinkedServiceImportsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"linked_services": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedServiceImportsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).linkedServicesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	workspaceID := d.Get("workspace_id").(string)
+	id, err := parseAzureResourceID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	resGroup := id.ResourceGroup
+	workspaceName := id.Path["workspaces"]
+	if workspaceName == "" {
+		return fmt.Errorf("Error parsing %q as a Log Analytics Workspace ID: `workspaces` segment was not found", workspaceID)
+	}
+
+	resp, err := client.ListByWorkspace(ctx, resGroup, workspaceName)
+	if err != nil {
+		return fmt.Errorf("Error listing Linked Services (Workspace %q / Resource Group %q): %+v", workspaceName, resGroup, err)
+	}
+
+	linkedServices := make([]interface{}, 0)
+	if resp.Value != nil {
+		for _, v := range *resp.Value {
+			if v.Name == nil {
+				continue
+			}
+
+			// the API returns the name in the format `{workspaceName}/{linkedServiceName}`
+			segments := strings.Split(*v.Name, "/")
+			name := strings.ToLower(segments[len(segments)-1])
+
+			linkedServices = append(linkedServices, map[string]interface{}{
+				"resource_type": "azurerm_log_analytics_workspace_linked_service",
+				"name":          name,
+				"id":            logAnalyticsWorkspaceLinkedServiceID(id.SubscriptionID, resGroup, workspaceName, name),
+			})
+		}
+	}
+
+	d.SetId(workspaceID)
+
+	if err := d.Set("linked_services", linkedServices); err != nil {
+		return fmt.Errorf("Error setting `linked_services`: %+v", err)
+	}
+
+	return nil
+}
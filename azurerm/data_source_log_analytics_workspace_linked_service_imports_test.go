@@ -0,0 +1,45 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServiceImports_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_log_analytics_workspace_linked_service_imports.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServiceImports_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.0.resource_type", "azurerm_log_analytics_workspace_linked_service"),
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.0.name", "automation"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "linked_services.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServiceImports_basic(rInt int, location string) string {
+	config := testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_log_analytics_workspace_linked_service_imports" "test" {
+  workspace_id = "${azurerm_log_analytics_workspace.test.id}"
+
+  depends_on = ["azurerm_log_analytics_workspace_linked_service.test"]
+}
+`, config)
+}
@@ -0,0 +1,74 @@
+package azurerm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", fmt.Sprintf("acctestlaw-%d/Automation", ri)),
+					resource.TestCheckResourceAttrPair(dataSourceName, "resource_id", "azurerm_automation_account.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "linked_service_properties.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "linked_service_properties.0.resource_id", "azurerm_automation_account.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_notFound(t *testing.T) {
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_notFound(ri, location),
+				ExpectError: regexp.MustCompile("was not found"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt int, location string) string {
+	config := testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${azurerm_log_analytics_workspace_linked_service.test.resource_group_name}"
+  workspace_name      = "${azurerm_log_analytics_workspace_linked_service.test.workspace_name}"
+}
+`, config)
+}
+
+func testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedService_notFound(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${azurerm_log_analytics_workspace.test.resource_group_name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+}
+`, template)
+}
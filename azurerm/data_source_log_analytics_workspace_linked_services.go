@@ -0,0 +1,135 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedServices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmLogAnalyticsWorkspaceLinkedServicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAzureRmLogAnalyticsWorkspaceName,
+			},
+
+			"tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateAzureRMTags,
+			},
+
+			"linked_services": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": tagsForDataSourceSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmLogAnalyticsWorkspaceLinkedServicesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).linkedServicesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resGroup := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+
+	// NOTE: this API isn't paged - all of the Linked Services within the Workspace are returned in a single response
+	resp, err := client.ListByWorkspace(ctx, resGroup, workspaceName)
+	if err != nil {
+		return fmt.Errorf("Error listing Linked Services (Workspace %q / Resource Group %q): %+v", workspaceName, resGroup, err)
+	}
+
+	d.SetId(logAnalyticsWorkspaceID(meta.(*ArmClient).subscriptionId, resGroup, workspaceName))
+
+	tags := expandTags(d.Get("tags").(map[string]interface{}))
+	if err := d.Set("linked_services", flattenLogAnalyticsWorkspaceLinkedServices(resp.Value, tags)); err != nil {
+		return fmt.Errorf("Error setting `linked_services`: %+v", err)
+	}
+
+	return nil
+}
+
+// flattenLogAnalyticsWorkspaceLinkedServices returns the Linked Services which have all of the specified tags - where the
+// tag keys are matched case-insensitively (as they are by Azure) and the values must match exactly
+func flattenLogAnalyticsWorkspaceLinkedServices(input *[]operationalinsights.LinkedService, tags map[string]*string) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		if v.Name == nil || !logAnalyticsWorkspaceLinkedServiceHasTags(v.Tags, tags) {
+			continue
+		}
+
+		// the API returns the name in the format `{workspaceName}/{linkedServiceName}`
+		segments := strings.Split(*v.Name, "/")
+		name := strings.ToLower(segments[len(segments)-1])
+
+		resourceID := ""
+		if props := v.LinkedServiceProperties; props != nil && props.ResourceID != nil {
+			resourceID = *props.ResourceID
+		}
+
+		linkedServiceTags := make(map[string]interface{}, len(v.Tags))
+		for key, value := range v.Tags {
+			if value != nil {
+				linkedServiceTags[key] = *value
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"name":        name,
+			"resource_id": resourceID,
+			"tags":        linkedServiceTags,
+		})
+	}
+
+	return output
+}
+
+func logAnalyticsWorkspaceLinkedServiceHasTags(actual map[string]*string, expected map[string]*string) bool {
+	for expectedKey, expectedValue := range expected {
+		found := false
+		for key, value := range actual {
+			if !strings.EqualFold(key, expectedKey) || value == nil || expectedValue == nil {
+				continue
+			}
+
+			if *value == *expectedValue {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
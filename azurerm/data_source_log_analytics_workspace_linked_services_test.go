@@ -0,0 +1,148 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServices_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_log_analytics_workspace_linked_services.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServices_basic(ri, location, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.0.name", "automation"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "linked_services.0.resource_id", "azurerm_automation_account.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.0.tags.environment", "test"),
+				),
+			},
+			{
+				Config: testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServices_basic(ri, location, "production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "linked_services.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenLogAnalyticsWorkspaceLinkedServices(t *testing.T) {
+	input := &[]operationalinsights.LinkedService{
+		{
+			Name: utils.String("workspace1/Automation"),
+			LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"),
+			},
+			Tags: map[string]*string{
+				"Environment": utils.String("production"),
+				"owner":       utils.String("ops"),
+			},
+		},
+		{
+			Name: utils.String("workspace1/Cluster"),
+			LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1"),
+			},
+			Tags: map[string]*string{
+				"environment": utils.String("test"),
+			},
+		},
+		{
+			// without a name the Linked Service can't be identified
+			Tags: map[string]*string{
+				"environment": utils.String("production"),
+			},
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Tags     map[string]*string
+		Expected []string
+	}{
+		{
+			Name:     "no filter",
+			Tags:     map[string]*string{},
+			Expected: []string{"automation", "cluster"},
+		},
+		{
+			Name: "tag key with a different casing",
+			Tags: map[string]*string{
+				"environment": utils.String("production"),
+			},
+			Expected: []string{"automation"},
+		},
+		{
+			Name: "multiple tags",
+			Tags: map[string]*string{
+				"environment": utils.String("production"),
+				"owner":       utils.String("ops"),
+			},
+			Expected: []string{"automation"},
+		},
+		{
+			Name: "tag value with a different casing",
+			Tags: map[string]*string{
+				"environment": utils.String("Production"),
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "no matches",
+			Tags: map[string]*string{
+				"environment": utils.String("staging"),
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := flattenLogAnalyticsWorkspaceLinkedServices(input, tc.Tags)
+			if len(actual) != len(tc.Expected) {
+				t.Fatalf("Expected %d Linked Services but got %d: %+v", len(tc.Expected), len(actual), actual)
+			}
+
+			for i, name := range tc.Expected {
+				if v := actual[i].(map[string]interface{})["name"]; v != name {
+					t.Fatalf("Expected the Linked Service at index %d to be %q but got %q", i, name, v)
+				}
+			}
+		})
+	}
+
+	if actual := flattenLogAnalyticsWorkspaceLinkedServices(nil, map[string]*string{}); len(actual) != 0 {
+		t.Fatalf("Expected no Linked Services but got %+v", actual)
+	}
+}
+
+func testAccDataSourceAzureRMLogAnalyticsWorkspaceLinkedServices_basic(rInt int, location string, environment string) string {
+	config := testAccAzureRMLogAnalyticsWorkspaceLinkedService_tags(rInt, location, "test")
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_log_analytics_workspace_linked_services" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+
+  tags {
+    environment = "%s"
+  }
+
+  depends_on = ["azurerm_log_analytics_workspace_linked_service.test"]
+}
+`, config, environment)
+}
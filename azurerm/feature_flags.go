@@ -7,3 +7,19 @@ import (
 
 // This file contains feature flags for functionality which will prove more challenging to implement en-mass
 var requireResourcesToBeImported = strings.EqualFold(os.Getenv("ARM_PROVIDER_STRICT"), "true")
+
+// when enabled, the Log Analytics Workspace Linked Service checks that the Workspace and linked Resource exist
+// (and can be read) during the plan, allowing the configuration to be validated without creating anything
+var validateLinkedServicesDuringPlan = strings.EqualFold(os.Getenv("ARM_PROVIDER_VALIDATE_LINKED_SERVICES"), "true")
+
+// when enabled, the Log Analytics Workspace Linked Service checks that the current credentials are able to write to the
+// Workspace and read the linked Resource prior to creating the Linked Service, which requires some additional API calls
+var verifyLinkedServicePermissions = strings.EqualFold(os.Getenv("ARM_PROVIDER_VERIFY_LINKED_SERVICE_PERMISSIONS"), "true")
+
+// when enabled, the Log Analytics Workspace Linked Service doesn't check that a linked Automation Account uses a SKU
+// which supports Update Management prior to creating the Linked Service
+var skipLinkedServiceAutomationAccountSkuCheck = strings.EqualFold(os.Getenv("ARM_PROVIDER_SKIP_LINKED_SERVICE_AUTOMATION_SKU_CHECK"), "true")
+
+// when enabled, creating a Log Analytics Workspace Linked Service replaces an existing Linked Service (of the same kind) within the
+// Workspace which links to a different Resource - rather than returning an error, since Azure silently moves the link
+var replaceExistingLinkedServices = strings.EqualFold(os.Getenv("ARM_PROVIDER_REPLACE_EXISTING_LINKED_SERVICES"), "true")
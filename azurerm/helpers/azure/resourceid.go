@@ -94,3 +94,50 @@ func ParseAzureResourceID(id string) (*ResourceID, error) {
 
 	return idObj, nil
 }
+
+// ResourceIDPathValue returns the value for the specified key within the Path of a ResourceID, matching the key
+// case-insensitively since some APIs don't consistently return the segments of an ID in camel-case
+func ResourceIDPathValue(path map[string]string, key string) string {
+	if v, ok := path[key]; ok {
+		return v
+	}
+
+	for k, v := range path {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// ParseAzureResourceType returns the fully-qualified type of the Resource represented by a
+// long-form Azure Resource Manager ID, e.g. `Microsoft.Sql/servers/databases`
+func ParseAzureResourceType(id string) (string, error) {
+	if _, err := ParseAzureResourceID(id); err != nil {
+		return "", err
+	}
+
+	path := strings.TrimPrefix(id, "/")
+	path = strings.TrimSuffix(path, "/")
+	components := strings.Split(path, "/")
+
+	// extension resources can be nested within other resources, so it's the last provider which matters
+	providerIndex := -1
+	for i := 0; i < len(components); i += 2 {
+		if strings.EqualFold(components[i], "providers") {
+			providerIndex = i
+		}
+	}
+
+	if providerIndex == -1 || providerIndex+2 >= len(components) {
+		return "", fmt.Errorf("No resource type found in: %q", id)
+	}
+
+	segments := []string{components[providerIndex+1]}
+	for i := providerIndex + 2; i < len(components); i += 2 {
+		segments = append(segments, components[i])
+	}
+
+	return strings.Join(segments, "/"), nil
+}
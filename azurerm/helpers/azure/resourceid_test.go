@@ -143,3 +143,79 @@ func TestParseAzureResourceID(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAzureResourceType(t *testing.T) {
+	testCases := []struct {
+		id           string
+		expectedType string
+		expectError  bool
+	}{
+		{
+			"random",
+			"",
+			true,
+		},
+		{
+			// resource groups have no type within the ID
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			"",
+			true,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			"Microsoft.Automation/automationAccounts",
+			false,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Sql/servers/server1/databases/database1",
+			"Microsoft.Sql/servers/databases",
+			false,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1/providers/Microsoft.Insights/diagnosticSettings/setting1",
+			"Microsoft.Insights/diagnosticSettings",
+			false,
+		},
+	}
+
+	for _, test := range testCases {
+		resourceType, err := ParseAzureResourceType(test.id)
+		if test.expectError && err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if test.expectError {
+			t.Fatalf("Expected an error for %q but didn't get one", test.id)
+		}
+
+		if resourceType != test.expectedType {
+			t.Fatalf("Expected %q but got %q for %q", test.expectedType, resourceType, test.id)
+		}
+	}
+}
+
+func TestResourceIDPathValue(t *testing.T) {
+	path := map[string]string{
+		"workspaces":  "workspace1",
+		"datasources": "datasource1",
+	}
+
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"workspaces", "workspace1"},
+		{"Workspaces", "workspace1"},
+		{"dataSources", "datasource1"},
+		{"DATASOURCES", "datasource1"},
+		{"linkedServices", ""},
+	}
+
+	for _, test := range testCases {
+		if value := ResourceIDPathValue(path, test.key); value != test.expected {
+			t.Fatalf("Expected %q but got %q for %q", test.expected, value, test.key)
+		}
+	}
+}
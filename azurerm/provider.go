@@ -98,64 +98,67 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"azurerm_api_management":                        dataSourceApiManagementService(),
-			"azurerm_app_service_plan":                      dataSourceAppServicePlan(),
-			"azurerm_app_service":                           dataSourceArmAppService(),
-			"azurerm_application_insights":                  dataSourceArmApplicationInsights(),
-			"azurerm_application_security_group":            dataSourceArmApplicationSecurityGroup(),
-			"azurerm_azuread_application":                   dataSourceArmAzureADApplication(),
-			"azurerm_azuread_service_principal":             dataSourceArmActiveDirectoryServicePrincipal(),
-			"azurerm_batch_account":                         dataSourceArmBatchAccount(),
-			"azurerm_batch_pool":                            dataSourceArmBatchPool(),
-			"azurerm_builtin_role_definition":               dataSourceArmBuiltInRoleDefinition(),
-			"azurerm_cdn_profile":                           dataSourceArmCdnProfile(),
-			"azurerm_client_config":                         dataSourceArmClientConfig(),
-			"azurerm_container_registry":                    dataSourceArmContainerRegistry(),
-			"azurerm_cosmosdb_account":                      dataSourceArmCosmosDBAccount(),
-			"azurerm_data_lake_store":                       dataSourceArmDataLakeStoreAccount(),
-			"azurerm_dev_test_lab":                          dataSourceArmDevTestLab(),
-			"azurerm_dns_zone":                              dataSourceArmDnsZone(),
-			"azurerm_eventhub_namespace":                    dataSourceEventHubNamespace(),
-			"azurerm_image":                                 dataSourceArmImage(),
-			"azurerm_key_vault_access_policy":               dataSourceArmKeyVaultAccessPolicy(),
-			"azurerm_key_vault_key":                         dataSourceArmKeyVaultKey(),
-			"azurerm_key_vault_secret":                      dataSourceArmKeyVaultSecret(),
-			"azurerm_key_vault":                             dataSourceArmKeyVault(),
-			"azurerm_kubernetes_cluster":                    dataSourceArmKubernetesCluster(),
-			"azurerm_lb":                                    dataSourceArmLoadBalancer(),
-			"azurerm_lb_backend_address_pool":               dataSourceArmLoadBalancerBackendAddressPool(),
-			"azurerm_log_analytics_workspace":               dataSourceLogAnalyticsWorkspace(),
-			"azurerm_logic_app_workflow":                    dataSourceArmLogicAppWorkflow(),
-			"azurerm_managed_disk":                          dataSourceArmManagedDisk(),
-			"azurerm_management_group":                      dataSourceArmManagementGroup(),
-			"azurerm_monitor_action_group":                  dataSourceArmMonitorActionGroup(),
-			"azurerm_monitor_diagnostic_categories":         dataSourceArmMonitorDiagnosticCategories(),
-			"azurerm_monitor_log_profile":                   dataSourceArmMonitorLogProfile(),
-			"azurerm_network_interface":                     dataSourceArmNetworkInterface(),
-			"azurerm_network_security_group":                dataSourceArmNetworkSecurityGroup(),
-			"azurerm_notification_hub_namespace":            dataSourceNotificationHubNamespace(),
-			"azurerm_notification_hub":                      dataSourceNotificationHub(),
-			"azurerm_platform_image":                        dataSourceArmPlatformImage(),
-			"azurerm_public_ip":                             dataSourceArmPublicIP(),
-			"azurerm_public_ips":                            dataSourceArmPublicIPs(),
-			"azurerm_recovery_services_vault":               dataSourceArmRecoveryServicesVault(),
-			"azurerm_resource_group":                        dataSourceArmResourceGroup(),
-			"azurerm_role_definition":                       dataSourceArmRoleDefinition(),
-			"azurerm_route_table":                           dataSourceArmRouteTable(),
-			"azurerm_scheduler_job_collection":              dataSourceArmSchedulerJobCollection(),
-			"azurerm_shared_image_gallery":                  dataSourceArmSharedImageGallery(),
-			"azurerm_shared_image_version":                  dataSourceArmSharedImageVersion(),
-			"azurerm_shared_image":                          dataSourceArmSharedImage(),
-			"azurerm_snapshot":                              dataSourceArmSnapshot(),
-			"azurerm_storage_account_sas":                   dataSourceArmStorageAccountSharedAccessSignature(),
-			"azurerm_storage_account":                       dataSourceArmStorageAccount(),
-			"azurerm_subnet":                                dataSourceArmSubnet(),
-			"azurerm_subscription":                          dataSourceArmSubscription(),
-			"azurerm_subscriptions":                         dataSourceArmSubscriptions(),
-			"azurerm_traffic_manager_geographical_location": dataSourceArmTrafficManagerGeographicalLocation(),
-			"azurerm_virtual_machine":                       dataSourceArmVirtualMachine(),
-			"azurerm_virtual_network_gateway":               dataSourceArmVirtualNetworkGateway(),
-			"azurerm_virtual_network":                       dataSourceArmVirtualNetwork(),
+			"azurerm_api_management":                                 dataSourceApiManagementService(),
+			"azurerm_app_service_plan":                               dataSourceAppServicePlan(),
+			"azurerm_app_service":                                    dataSourceArmAppService(),
+			"azurerm_application_insights":                           dataSourceArmApplicationInsights(),
+			"azurerm_application_security_group":                     dataSourceArmApplicationSecurityGroup(),
+			"azurerm_azuread_application":                            dataSourceArmAzureADApplication(),
+			"azurerm_azuread_service_principal":                      dataSourceArmActiveDirectoryServicePrincipal(),
+			"azurerm_batch_account":                                  dataSourceArmBatchAccount(),
+			"azurerm_batch_pool":                                     dataSourceArmBatchPool(),
+			"azurerm_builtin_role_definition":                        dataSourceArmBuiltInRoleDefinition(),
+			"azurerm_cdn_profile":                                    dataSourceArmCdnProfile(),
+			"azurerm_client_config":                                  dataSourceArmClientConfig(),
+			"azurerm_container_registry":                             dataSourceArmContainerRegistry(),
+			"azurerm_cosmosdb_account":                               dataSourceArmCosmosDBAccount(),
+			"azurerm_data_lake_store":                                dataSourceArmDataLakeStoreAccount(),
+			"azurerm_dev_test_lab":                                   dataSourceArmDevTestLab(),
+			"azurerm_dns_zone":                                       dataSourceArmDnsZone(),
+			"azurerm_eventhub_namespace":                             dataSourceEventHubNamespace(),
+			"azurerm_image":                                          dataSourceArmImage(),
+			"azurerm_key_vault_access_policy":                        dataSourceArmKeyVaultAccessPolicy(),
+			"azurerm_key_vault_key":                                  dataSourceArmKeyVaultKey(),
+			"azurerm_key_vault_secret":                               dataSourceArmKeyVaultSecret(),
+			"azurerm_key_vault":                                      dataSourceArmKeyVault(),
+			"azurerm_kubernetes_cluster":                             dataSourceArmKubernetesCluster(),
+			"azurerm_lb":                                             dataSourceArmLoadBalancer(),
+			"azurerm_lb_backend_address_pool":                        dataSourceArmLoadBalancerBackendAddressPool(),
+			"azurerm_log_analytics_workspace":                        dataSourceLogAnalyticsWorkspace(),
+			"azurerm_log_analytics_workspace_linked_service":         dataSourceArmLogAnalyticsWorkspaceLinkedService(),
+			"azurerm_log_analytics_workspace_linked_service_imports": dataSourceArmLogAnalyticsWorkspaceLinkedServiceImports(),
+			"azurerm_log_analytics_workspace_linked_services":        dataSourceArmLogAnalyticsWorkspaceLinkedServices(),
+			"azurerm_logic_app_workflow":                             dataSourceArmLogicAppWorkflow(),
+			"azurerm_managed_disk":                                   dataSourceArmManagedDisk(),
+			"azurerm_management_group":                               dataSourceArmManagementGroup(),
+			"azurerm_monitor_action_group":                           dataSourceArmMonitorActionGroup(),
+			"azurerm_monitor_diagnostic_categories":                  dataSourceArmMonitorDiagnosticCategories(),
+			"azurerm_monitor_log_profile":                            dataSourceArmMonitorLogProfile(),
+			"azurerm_network_interface":                              dataSourceArmNetworkInterface(),
+			"azurerm_network_security_group":                         dataSourceArmNetworkSecurityGroup(),
+			"azurerm_notification_hub_namespace":                     dataSourceNotificationHubNamespace(),
+			"azurerm_notification_hub":                               dataSourceNotificationHub(),
+			"azurerm_platform_image":                                 dataSourceArmPlatformImage(),
+			"azurerm_public_ip":                                      dataSourceArmPublicIP(),
+			"azurerm_public_ips":                                     dataSourceArmPublicIPs(),
+			"azurerm_recovery_services_vault":                        dataSourceArmRecoveryServicesVault(),
+			"azurerm_resource_group":                                 dataSourceArmResourceGroup(),
+			"azurerm_role_definition":                                dataSourceArmRoleDefinition(),
+			"azurerm_route_table":                                    dataSourceArmRouteTable(),
+			"azurerm_scheduler_job_collection":                       dataSourceArmSchedulerJobCollection(),
+			"azurerm_shared_image_gallery":                           dataSourceArmSharedImageGallery(),
+			"azurerm_shared_image_version":                           dataSourceArmSharedImageVersion(),
+			"azurerm_shared_image":                                   dataSourceArmSharedImage(),
+			"azurerm_snapshot":                                       dataSourceArmSnapshot(),
+			"azurerm_storage_account_sas":                            dataSourceArmStorageAccountSharedAccessSignature(),
+			"azurerm_storage_account":                                dataSourceArmStorageAccount(),
+			"azurerm_subnet":                                         dataSourceArmSubnet(),
+			"azurerm_subscription":                                   dataSourceArmSubscription(),
+			"azurerm_subscriptions":                                  dataSourceArmSubscriptions(),
+			"azurerm_traffic_manager_geographical_location":          dataSourceArmTrafficManagerGeographicalLocation(),
+			"azurerm_virtual_machine":                                dataSourceArmVirtualMachine(),
+			"azurerm_virtual_network_gateway":                        dataSourceArmVirtualNetworkGateway(),
+			"azurerm_virtual_network":                                dataSourceArmVirtualNetwork(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -242,6 +245,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_lb_rule":                                resourceArmLoadBalancerRule(),
 			"azurerm_lb":                                     resourceArmLoadBalancer(),
 			"azurerm_local_network_gateway":                  resourceArmLocalNetworkGateway(),
+			"azurerm_log_analytics_datasource_windows_event": resourceArmLogAnalyticsDataSourceWindowsEvent(),
 			"azurerm_log_analytics_solution":                 resourceArmLogAnalyticsSolution(),
 			"azurerm_log_analytics_workspace_linked_service": resourceArmLogAnalyticsWorkspaceLinkedService(),
 			"azurerm_log_analytics_workspace":                resourceArmLogAnalyticsWorkspace(),
@@ -0,0 +1,284 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// logAnalyticsDataSourceWindowsEventTypes are the types of Windows Event which can be collected, which the API
+// returns in lower-case (e.g. `error`)
+var logAnalyticsDataSourceWindowsEventTypes = []string{
+	"Error",
+	"Information",
+	"Warning",
+}
+
+func resourceArmLogAnalyticsDataSourceWindowsEvent() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmLogAnalyticsDataSourceWindowsEventCreateUpdate,
+		Read:   resourceArmLogAnalyticsDataSourceWindowsEventRead,
+		Update: resourceArmLogAnalyticsDataSourceWindowsEventCreateUpdate,
+		Delete: resourceArmLogAnalyticsDataSourceWindowsEventDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceArmLogAnalyticsDataSourceWindowsEventImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"workspace_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validateAzureRmLogAnalyticsWorkspaceName,
+			},
+
+			"event_log_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"event_types": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(logAnalyticsDataSourceWindowsEventTypes, false),
+				},
+				Set: schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceArmLogAnalyticsDataSourceWindowsEventCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).dataSourcesClient
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, timeout)
+	defer cancel()
+
+	log.Printf("[INFO] preparing arguments for AzureRM Log Analytics Windows Event Data Source creation.")
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+
+	if requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resGroup, workspaceName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Windows Event Data Source %q (Workspace %q / Resource Group %q): %s", name, workspaceName, resGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_log_analytics_datasource_windows_event", *existing.ID)
+		}
+	}
+
+	parameters := operationalinsights.DataSource{
+		Kind:       operationalinsights.WindowsEvent,
+		Properties: expandLogAnalyticsDataSourceWindowsEventProperties(d.Get("event_log_name").(string), d.Get("event_types").(*schema.Set).List()),
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, resGroup, workspaceName, name, parameters); err != nil {
+		return fmt.Errorf("Error creating/updating Windows Event Data Source %q (Workspace %q / Resource Group %q): %+v", name, workspaceName, resGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resGroup, workspaceName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Windows Event Data Source %q (Workspace %q / Resource Group %q): %+v", name, workspaceName, resGroup, err)
+	}
+
+	if resp.ID == nil {
+		return fmt.Errorf("Cannot read Windows Event Data Source %q (Workspace %q / Resource Group %q) ID", name, workspaceName, resGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceArmLogAnalyticsDataSourceWindowsEventRead(d, meta)
+}
+
+func resourceArmLogAnalyticsDataSourceWindowsEventRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).dataSourcesClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
+
+	id, err := parseLogAnalyticsDataSourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	workspaceName := id.Path["workspaces"]
+	name := id.Path["dataSources"]
+
+	resp, err := client.Get(ctx, resGroup, workspaceName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Windows Event Data Source %q was not found in Workspace %q (Resource Group %q) - removing from state", name, workspaceName, resGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error making Read request on Windows Event Data Source %q (Workspace %q / Resource Group %q): %+v", name, workspaceName, resGroup, err)
+	}
+
+	if resp.Kind != operationalinsights.WindowsEvent {
+		return fmt.Errorf("Expected Data Source %q (Workspace %q / Resource Group %q) to be of kind %q but got %q", name, workspaceName, resGroup, string(operationalinsights.WindowsEvent), string(resp.Kind))
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resGroup)
+	d.Set("workspace_name", workspaceName)
+
+	eventLogName, eventTypes := flattenLogAnalyticsDataSourceWindowsEventProperties(resp.Properties)
+	d.Set("event_log_name", eventLogName)
+	if err := d.Set("event_types", eventTypes); err != nil {
+		return fmt.Errorf("Error setting `event_types`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmLogAnalyticsDataSourceWindowsEventDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).dataSourcesClient
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	id, err := parseLogAnalyticsDataSourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	workspaceName := id.Path["workspaces"]
+	name := id.Path["dataSources"]
+
+	resp, err := client.Delete(ctx, resGroup, workspaceName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Windows Event Data Source %q (Workspace %q / Resource Group %q): %+v", name, workspaceName, resGroup, err)
+	}
+
+	return nil
+}
+
+func resourceArmLogAnalyticsDataSourceWindowsEventImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseLogAnalyticsDataSourceID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseLogAnalyticsDataSourceID parses a Data Source ID, ensuring it's in the format
+// `.../providers/Microsoft.OperationalInsights/workspaces/{workspaceName}/dataSources/{dataSourceName}` - the keys are
+// matched case-insensitively, since the API doesn't consistently return `dataSources` in camel-case
+func parseLogAnalyticsDataSourceID(input string) (*ResourceID, error) {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Log Analytics Data Source ID %q: %+v", input, err)
+	}
+
+	if !strings.EqualFold(id.Provider, "Microsoft.OperationalInsights") {
+		return nil, fmt.Errorf("Expected the Log Analytics Data Source ID %q to be for the provider `Microsoft.OperationalInsights` but got %q", input, id.Provider)
+	}
+
+	workspaceName := azure.ResourceIDPathValue(id.Path, "workspaces")
+	dataSourceName := azure.ResourceIDPathValue(id.Path, "dataSources")
+	if workspaceName == "" || dataSourceName == "" || len(id.Path) != 2 {
+		return nil, fmt.Errorf("Expected the Log Analytics Data Source ID %q to be in the format `.../providers/Microsoft.OperationalInsights/workspaces/{workspaceName}/dataSources/{dataSourceName}`", input)
+	}
+
+	id.Path = map[string]string{
+		"workspaces":  workspaceName,
+		"dataSources": dataSourceName,
+	}
+
+	return id, nil
+}
+
+func expandLogAnalyticsDataSourceWindowsEventProperties(eventLogName string, input []interface{}) map[string]interface{} {
+	eventTypes := make([]interface{}, 0)
+	for _, v := range input {
+		eventTypes = append(eventTypes, map[string]interface{}{
+			"eventType": strings.ToLower(v.(string)),
+		})
+	}
+
+	return map[string]interface{}{
+		"eventLogName": eventLogName,
+		"eventTypes":   eventTypes,
+	}
+}
+
+// flattenLogAnalyticsDataSourceWindowsEventProperties returns the Event Log Name and the Event Types from the
+// Data Source Properties, which the SDK returns as raw JSON (e.g. a `map[string]interface{}`)
+func flattenLogAnalyticsDataSourceWindowsEventProperties(input interface{}) (string, []interface{}) {
+	eventTypes := make([]interface{}, 0)
+
+	properties, ok := input.(map[string]interface{})
+	if !ok {
+		return "", eventTypes
+	}
+
+	eventLogName, _ := properties["eventLogName"].(string)
+
+	if types, ok := properties["eventTypes"].([]interface{}); ok {
+		for _, raw := range types {
+			v, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			eventType, _ := v["eventType"].(string)
+			for _, allowed := range logAnalyticsDataSourceWindowsEventTypes {
+				if strings.EqualFold(eventType, allowed) {
+					eventType = allowed
+					break
+				}
+			}
+
+			if eventType != "" {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+
+	return eventLogName, eventTypes
+}
@@ -0,0 +1,340 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+)
+
+func TestAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(t *testing.T) {
+	resourceName := "azurerm_log_analytics_datasource_windows_event.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsDataSourceWindowsEventDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsDataSourceWindowsEventExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "event_log_name", "Application"),
+					resource.TestCheckResourceAttr(resourceName, "event_types.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsDataSourceWindowsEvent_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_log_analytics_datasource_windows_event.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsDataSourceWindowsEventDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsDataSourceWindowsEventExists(resourceName),
+				),
+			},
+			{
+				Config:      testAccAzureRMLogAnalyticsDataSourceWindowsEvent_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_log_analytics_datasource_windows_event"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsDataSourceWindowsEvent_update(t *testing.T) {
+	resourceName := "azurerm_log_analytics_datasource_windows_event.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsDataSourceWindowsEventDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsDataSourceWindowsEventExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "event_log_name", "Application"),
+					resource.TestCheckResourceAttr(resourceName, "event_types.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMLogAnalyticsDataSourceWindowsEvent_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsDataSourceWindowsEventExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "event_log_name", "System"),
+					resource.TestCheckResourceAttr(resourceName, "event_types.#", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseLogAnalyticsDataSourceID(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+		Valid bool
+	}{
+		{
+			Name:  "empty",
+			Input: "",
+			Valid: false,
+		},
+		{
+			Name:  "workspace",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1",
+			Valid: false,
+		},
+		{
+			Name:  "linked service",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/automation",
+			Valid: false,
+		},
+		{
+			Name:  "different provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationsManagement/workspaces/workspace1/dataSources/datasource1",
+			Valid: false,
+		},
+		{
+			Name:  "data source",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/dataSources/datasource1",
+			Valid: true,
+		},
+		{
+			Name:  "lower-cased data source",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/datasources/datasource1",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			id, err := parseLogAnalyticsDataSourceID(tc.Input)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid && err == nil {
+				t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Input)
+			}
+
+			if tc.Valid && (id.Path["workspaces"] != "workspace1" || id.Path["dataSources"] != "datasource1") {
+				t.Fatalf("Expected the Workspace `workspace1` and Data Source `datasource1` but got %+v", id.Path)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsDataSourceWindowsEventProperties(t *testing.T) {
+	expanded := expandLogAnalyticsDataSourceWindowsEventProperties("Application", []interface{}{"Error", "Warning"})
+	expectedExpanded := map[string]interface{}{
+		"eventLogName": "Application",
+		"eventTypes": []interface{}{
+			map[string]interface{}{"eventType": "error"},
+			map[string]interface{}{"eventType": "warning"},
+		},
+	}
+	if !reflect.DeepEqual(expanded, expectedExpanded) {
+		t.Fatalf("Expected the Properties to be %+v but got %+v", expectedExpanded, expanded)
+	}
+
+	cases := []struct {
+		Name                 string
+		Input                interface{}
+		ExpectedEventLogName string
+		ExpectedEventTypes   []interface{}
+	}{
+		{
+			Name:               "nil",
+			Input:              nil,
+			ExpectedEventTypes: []interface{}{},
+		},
+		{
+			Name: "returned by the API",
+			Input: map[string]interface{}{
+				"eventLogName": "System",
+				"eventTypes": []interface{}{
+					map[string]interface{}{"eventType": "error"},
+					map[string]interface{}{"eventType": "Information"},
+					map[string]interface{}{"eventType": "WARNING"},
+				},
+			},
+			ExpectedEventLogName: "System",
+			ExpectedEventTypes:   []interface{}{"Error", "Information", "Warning"},
+		},
+		{
+			Name: "malformed event types",
+			Input: map[string]interface{}{
+				"eventLogName": "System",
+				"eventTypes": []interface{}{
+					"error",
+					map[string]interface{}{},
+				},
+			},
+			ExpectedEventLogName: "System",
+			ExpectedEventTypes:   []interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			eventLogName, eventTypes := flattenLogAnalyticsDataSourceWindowsEventProperties(tc.Input)
+			if eventLogName != tc.ExpectedEventLogName {
+				t.Fatalf("Expected the Event Log Name %q but got %q", tc.ExpectedEventLogName, eventLogName)
+			}
+
+			if !reflect.DeepEqual(eventTypes, tc.ExpectedEventTypes) {
+				t.Fatalf("Expected the Event Types %+v but got %+v", tc.ExpectedEventTypes, eventTypes)
+			}
+		})
+	}
+}
+
+func testCheckAzureRMLogAnalyticsDataSourceWindowsEventDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*ArmClient).dataSourcesClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_log_analytics_datasource_windows_event" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		workspaceName := rs.Primary.Attributes["workspace_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := conn.Get(ctx, resourceGroup, workspaceName, name)
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Log Analytics Windows Event Data Source still exists:\n%#v", resp)
+		}
+	}
+
+	return nil
+}
+
+func testCheckAzureRMLogAnalyticsDataSourceWindowsEventExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		workspaceName := rs.Primary.Attributes["workspace_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for Log Analytics Windows Event Data Source: %q", name)
+		}
+
+		conn := testAccProvider.Meta().(*ArmClient).dataSourcesClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := conn.Get(ctx, resourceGroup, workspaceName, name)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on Log Analytics Data Sources Client: %+v", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Log Analytics Windows Event Data Source %q (Workspace %q / Resource Group %q) does not exist", name, workspaceName, resourceGroup)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMLogAnalyticsDataSourceWindowsEvent_template(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctestLAW-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "PerGB2018"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsDataSourceWindowsEvent_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_datasource_windows_event" "test" {
+  name                = "acctestLADS-WE-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  event_log_name      = "Application"
+  event_types         = ["Error"]
+}
+`, template, rInt)
+}
+
+func testAccAzureRMLogAnalyticsDataSourceWindowsEvent_requiresImport(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsDataSourceWindowsEvent_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_datasource_windows_event" "import" {
+  name                = "${azurerm_log_analytics_datasource_windows_event.test.name}"
+  resource_group_name = "${azurerm_log_analytics_datasource_windows_event.test.resource_group_name}"
+  workspace_name      = "${azurerm_log_analytics_datasource_windows_event.test.workspace_name}"
+  event_log_name      = "${azurerm_log_analytics_datasource_windows_event.test.event_log_name}"
+  event_types         = ["Error"]
+}
+`, template)
+}
+
+func testAccAzureRMLogAnalyticsDataSourceWindowsEvent_complete(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsDataSourceWindowsEvent_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_datasource_windows_event" "test" {
+  name                = "acctestLADS-WE-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  event_log_name      = "System"
+  event_types         = ["Error", "Information", "Warning"]
+}
+`, template, rInt)
+}
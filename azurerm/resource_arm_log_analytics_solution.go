@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/operationsmanagement/mgmt/2015-11-01-preview/operationsmanagement"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
@@ -22,7 +23,7 @@ func resourceArmLogAnalyticsSolution() *schema.Resource {
 		Update: resourceArmLogAnalyticsSolutionCreateUpdate,
 		Delete: resourceArmLogAnalyticsSolutionDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmLogAnalyticsSolutionImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -45,6 +46,7 @@ func resourceArmLogAnalyticsSolution() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     azure.ValidateResourceID,
 			},
 
 			"location": locationSchema(),
@@ -148,7 +150,7 @@ func resourceArmLogAnalyticsSolutionCreateUpdate(d *schema.ResourceData, meta in
 func resourceArmLogAnalyticsSolutionRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).solutionsClient
 	ctx := meta.(*ArmClient).StopContext
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLogAnalyticsSolutionID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -202,7 +204,7 @@ func resourceArmLogAnalyticsSolutionRead(d *schema.ResourceData, meta interface{
 func resourceArmLogAnalyticsSolutionDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).solutionsClient
 	ctx := meta.(*ArmClient).StopContext
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLogAnalyticsSolutionID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -223,6 +225,33 @@ func resourceArmLogAnalyticsSolutionDelete(d *schema.ResourceData, meta interfac
 	return nil
 }
 
+func resourceArmLogAnalyticsSolutionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseLogAnalyticsSolutionID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseLogAnalyticsSolutionID parses a Solution ID, ensuring it's in the format
+// `.../providers/Microsoft.OperationsManagement/solutions/{solutionName}({workspaceName})`
+func parseLogAnalyticsSolutionID(input string) (*ResourceID, error) {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Log Analytics Solution ID %q: %+v", input, err)
+	}
+
+	if !strings.EqualFold(id.Provider, "Microsoft.OperationsManagement") {
+		return nil, fmt.Errorf("Expected the Log Analytics Solution ID %q to be for the provider `Microsoft.OperationsManagement` but got %q", input, id.Provider)
+	}
+
+	if id.Path["solutions"] == "" || len(id.Path) != 1 {
+		return nil, fmt.Errorf("Expected the Log Analytics Solution ID %q to be in the format `.../providers/Microsoft.OperationsManagement/solutions/{solutionName}({workspaceName})`", input)
+	}
+
+	return id, nil
+}
+
 func expandAzureRmLogAnalyticsSolutionPlan(d *schema.ResourceData) operationsmanagement.SolutionPlan {
 	plans := d.Get("plan").([]interface{})
 	plan := plans[0].(map[string]interface{})
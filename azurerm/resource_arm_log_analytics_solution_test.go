@@ -86,6 +86,61 @@ func TestAccAzureRMLogAnalyticsSolution_basicSecurity(t *testing.T) {
 	})
 }
 
+func TestParseLogAnalyticsSolutionID(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+		Valid bool
+	}{
+		{
+			Name:  "empty",
+			Input: "",
+			Valid: false,
+		},
+		{
+			Name:  "resource group",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			Valid: false,
+		},
+		{
+			Name:  "different provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/solutions/ContainerInsights(workspace1)",
+			Valid: false,
+		},
+		{
+			Name:  "nested too deeply",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationsManagement/solutions/ContainerInsights(workspace1)/child/name",
+			Valid: false,
+		},
+		{
+			Name:  "solution",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationsManagement/solutions/ContainerInsights(workspace1)",
+			Valid: true,
+		},
+		{
+			Name:  "lower-cased provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/microsoft.operationsmanagement/solutions/ContainerInsights(workspace1)",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			id, err := parseLogAnalyticsSolutionID(tc.Input)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid && err == nil {
+				t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Input)
+			}
+
+			if tc.Valid && id.Path["solutions"] != "ContainerInsights(workspace1)" {
+				t.Fatalf("Expected the Solution `ContainerInsights(workspace1)` but got %+v", id.Path)
+			}
+		})
+	}
+}
+
 func testCheckAzureRMLogAnalyticsSolutionDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*ArmClient).solutionsClient
 	ctx := testAccProvider.Meta().(*ArmClient).StopContext
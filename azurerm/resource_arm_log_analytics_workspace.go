@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -12,6 +13,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+const logAnalyticsWorkspaceResourceName = "azurerm_log_analytics_workspace"
+
 func resourceArmLogAnalyticsWorkspace() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmLogAnalyticsWorkspaceCreateUpdate,
@@ -203,6 +206,14 @@ func resourceArmLogAnalyticsWorkspaceDelete(d *schema.ResourceData, meta interfa
 	resGroup := id.ResourceGroup
 	name := id.Path["workspaces"]
 
+	// ensure any Linked Services within this Workspace have finished deleting first
+	lockName, err := logAnalyticsWorkspaceLockNameFromID(d.Id())
+	if err != nil {
+		return err
+	}
+	azureRMLockByName(lockName, logAnalyticsWorkspaceResourceName)
+	defer azureRMUnlockByName(lockName, logAnalyticsWorkspaceResourceName)
+
 	resp, err := client.Delete(ctx, resGroup, name)
 
 	if err != nil {
@@ -216,11 +227,39 @@ func resourceArmLogAnalyticsWorkspaceDelete(d *schema.ResourceData, meta interfa
 	return nil
 }
 
+// logAnalyticsWorkspaceLockName returns the key used to lock a Log Analytics Workspace - this is the Resource ID
+// of the Workspace (rather than it's name) so that operations against different Workspaces can run in parallel
+func logAnalyticsWorkspaceLockName(subscriptionId, resourceGroup, name string) string {
+	return strings.ToLower(logAnalyticsWorkspaceID(subscriptionId, resourceGroup, name))
+}
+
+// logAnalyticsWorkspaceLockNameFromID returns the key used to lock the Log Analytics Workspace with the specified Resource ID
+func logAnalyticsWorkspaceLockNameFromID(input string) (string, error) {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return "", err
+	}
+
+	name := id.Path["workspaces"]
+	if name == "" {
+		return "", fmt.Errorf("Expected the Log Analytics Workspace ID %q to contain the segment `workspaces`", input)
+	}
+
+	return logAnalyticsWorkspaceLockName(id.SubscriptionID, id.ResourceGroup, name), nil
+}
+
+// logAnalyticsWorkspaceID returns the Resource ID of a Log Analytics Workspace
+func logAnalyticsWorkspaceID(subscriptionId, resourceGroup, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s", subscriptionId, resourceGroup, name)
+}
+
+// compiled once since this is validated for every Workspace and Linked Service in the configuration
+var logAnalyticsWorkspaceNameRegExp = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9-]+[A-Za-z0-9]$")
+
 func validateAzureRmLogAnalyticsWorkspaceName(v interface{}, _ string) (warnings []string, errors []error) {
 	value := v.(string)
 
-	r, _ := regexp.Compile("^[A-Za-z0-9][A-Za-z0-9-]+[A-Za-z0-9]$")
-	if !r.MatchString(value) {
+	if !logAnalyticsWorkspaceNameRegExp.MatchString(value) {
 		errors = append(errors, fmt.Errorf("Workspace Name can only contain alphabet, number, and '-' character. You can not use '-' as the start and end of the name"))
 	}
 
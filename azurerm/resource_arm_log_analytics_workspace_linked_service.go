@@ -1,12 +1,24 @@
 package azurerm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/automation/mgmt/2015-10-31/automation"
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-01-01-preview/authorization"
 	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationsmanagement/mgmt/2015-11-01-preview/operationsmanagement"
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -16,8 +28,7 @@ import (
 /*
 TODO: refactor this:
 
- * resource_group_name/workspace_name can become case-sensitive
- * linked_service_properties should be a list / removed in favour of the top level element?
+ * resource_group_name can become case-sensitive
  * we can remove `workspace` from the resource name?
 */
 func resourceArmLogAnalyticsWorkspaceLinkedService() *schema.Resource {
@@ -28,9 +39,21 @@ func resourceArmLogAnalyticsWorkspaceLinkedService() *schema.Resource {
 		Delete: resourceArmLogAnalyticsWorkspaceLinkedServiceDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmLogAnalyticsWorkspaceLinkedServiceImport,
 		},
 
+		CustomizeDiff: resourceArmLogAnalyticsWorkspaceLinkedServiceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		MigrateState:  resourceAzureRMLogAnalyticsWorkspaceLinkedServiceMigrateState,
+		SchemaVersion: 2,
+
 		Schema: map[string]*schema.Schema{
 			"resource_group_name": resourceGroupNameDiffSuppressSchema(),
 
@@ -43,45 +66,102 @@ func resourceArmLogAnalyticsWorkspaceLinkedService() *schema.Resource {
 			},
 
 			"linked_service_name": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "automation",
-				ValidateFunc: validation.StringInSlice([]string{
-					"automation",
-				}, false),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "automation",
+				DiffSuppressFunc: suppress.CaseDifference,
+				ValidateFunc:     validateLogAnalyticsWorkspaceLinkedServiceName,
+			},
+
+			"resource_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: logAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress,
+				ValidateFunc:     azure.ValidateResourceID,
+				ConflictsWith:    []string{"linked_service_properties"},
 			},
 
 			"linked_service_properties": {
-				Type:     schema.TypeMap,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				Deprecated:    "This property has been replaced by the `resource_id` field and will be removed in version 2.0 of the provider",
+				ConflictsWith: []string{"resource_id"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"resource_id": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ForceNew:     true,
-							ValidateFunc: azure.ValidateResourceID,
+							Type:             schema.TypeString,
+							Required:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: logAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress,
+							ValidateFunc:     azure.ValidateResourceID,
 						},
 					},
 				},
 			},
 
+			"purge_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			// Exported properties
 			"name": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
-			"tags": tagsSchema(),
+			"kind": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"linked_resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"automation_account_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"workspace_customer_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"workspace_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsWithoutEmptyValuesSchema(),
 		},
 	}
 }
 
 func resourceArmLogAnalyticsWorkspaceLinkedServiceCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).linkedServicesClient
-	ctx := meta.(*ArmClient).StopContext
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, timeout)
+	defer cancel()
 
 	log.Printf("[INFO] preparing arguments for AzureRM Log Analytics Linked Services creation.")
 
@@ -102,25 +182,152 @@ func resourceArmLogAnalyticsWorkspaceLinkedServiceCreateUpdate(d *schema.Resourc
 		}
 	}
 
-	props := d.Get("linked_service_properties").(map[string]interface{})
-	resourceID := props["resource_id"].(string)
+	// Linked Services within the same Workspace can't be modified concurrently
+	lockName := logAnalyticsWorkspaceLockName(meta.(*ArmClient).subscriptionId, resGroup, workspaceName)
+	azureRMLockByName(lockName, logAnalyticsWorkspaceResourceName)
+	defer azureRMUnlockByName(lockName, logAnalyticsWorkspaceResourceName)
+
+	if d.Get("resource_id").(string) == "" {
+		if err := validateLogAnalyticsWorkspaceLinkedServiceProperties(d.Get("linked_service_properties").([]interface{})); err != nil {
+			return fmt.Errorf("Error creating Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+	}
+
+	resourceID := expandLogAnalyticsWorkspaceLinkedServiceResourceID(d.Get("resource_id").(string), d.Get("linked_service_properties").([]interface{}))
+	if resourceID == "" && d.IsNewResource() {
+		return fmt.Errorf("Error creating Linked Service %q (Workspace %q / Resource Group %q): one of `resource_id` or `linked_service_properties` must be specified", lsName, workspaceName, resGroup)
+	}
+
+	// when the Workspace already has a Linked Service of this kind Azure silently re-links it, so require this to be opted into
+	if !requireResourcesToBeImported && d.IsNewResource() {
+		existing, err := client.Get(ctx, resGroup, workspaceName, lsName)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("Error checking for presence of existing Linked Service %q (Workspace %q / Resource Group %q): %s", lsName, workspaceName, resGroup, err)
+			}
+		} else if err := validateLogAnalyticsWorkspaceLinkedServiceReplacement(existing, resourceID, replaceExistingLinkedServices); err != nil {
+			return fmt.Errorf("Linked Service %q can't be created in Log Analytics Workspace %q (Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+	}
+
+	// the Workspace and the linked Resource are each retrieved once, since they're used by several of the checks below
+	if d.IsNewResource() {
+		workspacesClient := meta.(*ArmClient).workspacesClient
+		workspace, err := workspacesClient.Get(ctx, resGroup, workspaceName)
+		if err != nil {
+			// the credentials in use may be able to write Linked Services without being able to read the Workspace
+			if !utils.ResponseWasForbidden(workspace.Response) {
+				return fmt.Errorf("Error retrieving Log Analytics Workspace %q (Resource Group %q): %+v", workspaceName, resGroup, err)
+			}
+
+			log.Printf("[WARN] Unable to retrieve Log Analytics Workspace %q (Resource Group %q) to check it's SKU: %+v", workspaceName, resGroup, err)
+		} else if err := validateLogAnalyticsWorkspaceLinkedServiceWorkspaceSku(workspace.Sku); err != nil {
+			return fmt.Errorf("Linked Service %q can't be created in Log Analytics Workspace %q (Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+
+		// NOTE: there's no SDK for Log Analytics Clusters available at this time, so only Automation Accounts are checked
+		id, err := parseAzureResourceID(resourceID)
+		if err == nil && id.Path["automationAccounts"] != "" && (requireResourcesToBeImported || !skipLinkedServiceAutomationAccountSkuCheck) {
+			automationClient := meta.(*ArmClient).automationAccountClient
+			accountName := id.Path["automationAccounts"]
+			account, accountErr := automationClient.Get(ctx, id.ResourceGroup, accountName)
+
+			// if the linked Resource has been deleted out-of-band Azure returns a fairly generic error, so check it exists first
+			if requireResourcesToBeImported {
+				err := validateLogAnalyticsWorkspaceLinkedServiceResourceExists(resourceID, func() (autorest.Response, error) {
+					return account.Response, accountErr
+				})
+				if err != nil {
+					return fmt.Errorf("Error creating Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+				}
+			}
+
+			if !skipLinkedServiceAutomationAccountSkuCheck {
+				err := validateLogAnalyticsWorkspaceLinkedServiceAutomationAccountSku(func() (automation.Account, error) {
+					return account, accountErr
+				})
+				if err != nil {
+					return fmt.Errorf("Linked Service %q can't be created for Automation Account %q (Resource Group %q): %+v", lsName, accountName, id.ResourceGroup, err)
+				}
+			}
+		}
+	}
+
+	if verifyLinkedServicePermissions && d.IsNewResource() {
+		permissionsClient := meta.(*ArmClient).permissionsClient
+		if err := verifyLogAnalyticsWorkspaceLinkedServicePermissions(ctx, permissionsClient, resGroup, workspaceName, resourceID); err != nil {
+			return err
+		}
+	}
+
+	tags := expandTags(d.Get("tags").(map[string]interface{}))
+	properties := &operationalinsights.LinkedServiceProperties{
+		ResourceID: utils.String(resourceID),
+	}
+
+	if !d.IsNewResource() {
+		existing, err := client.Get(ctx, resGroup, workspaceName, lsName)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+
+		// when the tags aren't changing (e.g. they're in `ignore_changes`) preserve the existing tags,
+		// rather than removing any which are managed outside of Terraform
+		if !d.HasChange("tags") {
+			tags = existing.Tags
+		}
 
-	tags := d.Get("tags").(map[string]interface{})
+		// only the properties which are changing are sent, so that an update (e.g. to the tags) can't change the linked Resource
+		resourceIDChanged := d.HasChange("resource_id") || d.HasChange("linked_service_properties")
+		properties = mergeLogAnalyticsWorkspaceLinkedServiceProperties(existing.LinkedServiceProperties, resourceID, resourceIDChanged)
+	}
 
 	parameters := operationalinsights.LinkedService{
-		Tags: expandTags(tags),
-		LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
-			ResourceID: &resourceID,
-		},
+		Tags:                    tags,
+		LinkedServiceProperties: properties,
 	}
 
-	if _, err := client.CreateOrUpdate(ctx, resGroup, workspaceName, lsName, parameters); err != nil {
+	log.Printf("[DEBUG] Creating/updating Linked Service %q (Workspace %q / Resource Group %q) with the payload: %s", lsName, workspaceName, resGroup, logAnalyticsWorkspaceLinkedServiceRedactedPayload(parameters))
+	var resp operationalinsights.LinkedService
+	err := retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(ctx, fmt.Sprintf("Creating Linked Service %q (Workspace %q / Resource Group %q)", lsName, workspaceName, resGroup), func() (autorest.Response, error) {
+		var err error
+		resp, err = client.CreateOrUpdate(ctx, resGroup, workspaceName, lsName, parameters)
+		return resp.Response, err
+	})
+	if err != nil {
+		if utils.ResponseWasForbidden(resp.Response) {
+			return logAnalyticsWorkspaceLinkedServiceForbiddenError(logAnalyticsWorkspaceLinkedServiceWriteAction, lsName, workspaceName, resGroup, err)
+		}
+		if serviceError := logAnalyticsWorkspaceLinkedServiceServiceError(err); serviceError != nil {
+			return fmt.Errorf("Error creating Linked Service %q (Workspace %q / Resource Group %q): %s: %+v", lsName, workspaceName, resGroup, serviceError.Error(), err)
+		}
 		return fmt.Errorf("Error creating Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
 	}
 
-	read, err := client.Get(ctx, resGroup, workspaceName, lsName)
+	get := func() (operationalinsights.LinkedService, error) {
+		var linkedService operationalinsights.LinkedService
+		err := retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(ctx, fmt.Sprintf("Retrieving Linked Service %q (Workspace %q / Resource Group %q)", lsName, workspaceName, resGroup), func() (autorest.Response, error) {
+			var err error
+			linkedService, err = client.Get(ctx, resGroup, workspaceName, lsName)
+			return linkedService.Response, err
+		})
+		return linkedService, err
+	}
+	list := func() (operationalinsights.LinkedServiceListResult, error) {
+		return client.ListByWorkspace(ctx, resGroup, workspaceName)
+	}
+
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(ctx, get, list, lsName, timeout); err != nil {
+		return fmt.Errorf("Error waiting for Linked Service %q (Workspace %q / Resource Group %q) to become available: %+v", lsName, workspaceName, resGroup, err)
+	}
+
+	// the tags returned from the API can lag behind those which were sent, so wait for them to match to avoid a false diff
+	read, err := waitForLogAnalyticsWorkspaceLinkedServiceTags(ctx, func() (operationalinsights.LinkedService, error) {
+		linkedService, _, err := getLogAnalyticsWorkspaceLinkedServiceFallingBackToList(get, list, lsName)
+		return linkedService, err
+	}, parameters.Tags)
 	if err != nil {
-		return fmt.Errorf("Error retrieving Linked Service %q (Worksppce %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		return fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
 	}
 	if read.ID == nil {
 		return fmt.Errorf("Cannot read Linked Service %q (Workspace %q / Resource Group %q) ID", lsName, workspaceName, resGroup)
@@ -133,9 +340,10 @@ func resourceArmLogAnalyticsWorkspaceLinkedServiceCreateUpdate(d *schema.Resourc
 
 func resourceArmLogAnalyticsWorkspaceLinkedServiceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).linkedServicesClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLogAnalyticsWorkspaceLinkedServiceID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -144,38 +352,108 @@ func resourceArmLogAnalyticsWorkspaceLinkedServiceRead(d *schema.ResourceData, m
 	workspaceName := id.Path["workspaces"]
 	lsName := id.Path["linkedServices"]
 
-	resp, err := client.Get(ctx, resGroup, workspaceName, lsName)
+	// IDs which were imported from the Portal (or stored by older versions of the Provider) can use a different
+	// casing for the keys within the ID, so this is reset to the canonical form
+	if canonicalID := logAnalyticsWorkspaceLinkedServiceID(id.SubscriptionID, resGroup, workspaceName, lsName); d.Id() != canonicalID {
+		log.Printf("[DEBUG] Updating the ID of Linked Service %q from %q to %q", lsName, d.Id(), canonicalID)
+		d.SetId(canonicalID)
+	}
+
+	get := func() (operationalinsights.LinkedService, error) {
+		return client.Get(ctx, resGroup, workspaceName, lsName)
+	}
+	list := func() (operationalinsights.LinkedServiceListResult, error) {
+		return client.ListByWorkspace(ctx, resGroup, workspaceName)
+	}
+	getWorkspace := func() (operationalinsights.Workspace, error) {
+		return meta.(*ArmClient).workspacesClient.Get(ctx, resGroup, workspaceName)
+	}
+
+	linkedService, err := getLogAnalyticsWorkspaceLinkedServiceForRead(get, list, getWorkspace, resGroup, workspaceName, lsName)
 	if err != nil {
-		if utils.ResponseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
-		}
-		return fmt.Errorf("Error making Read request on AzureRM Log Analytics Linked Service '%s': %+v", lsName, err)
+		return err
 	}
-	if resp.ID == nil {
+	if linkedService == nil {
 		d.SetId("")
 		return nil
 	}
 
+	resp := *linkedService
+
+	// `resource_group_name` is case-insensitive, so use the casing from Azure to ensure references to it are consistent
+	if resp.ID != nil {
+		if canonical := logAnalyticsWorkspaceLinkedServiceResourceGroupName(resGroup, *resp.ID); canonical != resGroup {
+			log.Printf("[DEBUG] Updating the Resource Group of Linked Service %q from %q to %q", lsName, resGroup, canonical)
+			resGroup = canonical
+			d.SetId(logAnalyticsWorkspaceLinkedServiceID(id.SubscriptionID, resGroup, workspaceName, lsName))
+		}
+	}
+
 	d.Set("name", resp.Name)
 	d.Set("resource_group_name", resGroup)
-	d.Set("workspace_name", workspaceName)
-	d.Set("linked_service_name", lsName)
+	// the API can return the Workspace name in a different casing to the one specified, which would otherwise cause a diff
+	configuredWorkspaceName := d.Get("workspace_name").(string)
+	d.Set("workspace_name", logAnalyticsWorkspaceLinkedServicePreserveCasing(configuredWorkspaceName, workspaceName))
+	d.Set("linked_service_name", logAnalyticsWorkspaceLinkedServicePreserveCasing(d.Get("linked_service_name").(string), lsName))
+
+	d.Set("kind", logAnalyticsWorkspaceLinkedServiceKind(resp.LinkedServiceProperties))
+
+	linkedResourceType := ""
+	automationAccountName := ""
+	if props := resp.LinkedServiceProperties; props != nil && props.ResourceID != nil {
+		linkedResourceType = logAnalyticsWorkspaceLinkedServiceResourceType(*props.ResourceID)
+		automationAccountName = logAnalyticsWorkspaceLinkedServiceAutomationAccountName(*props.ResourceID)
+	}
+	d.Set("linked_resource_type", linkedResourceType)
+	d.Set("automation_account_name", automationAccountName)
+
+	// the Customer ID of a Workspace never changes, so there's no need to look it up again once it's known
+	if d.Get("workspace_customer_id").(string) == "" {
+		workspacesClient := meta.(*ArmClient).workspacesClient
+		workspace, err := workspacesClient.Get(ctx, resGroup, workspaceName)
+		if err != nil {
+			// this is informational, so the Linked Service can still be managed without read access to the Workspace
+			log.Printf("[WARN] Unable to retrieve the Customer ID for Log Analytics Workspace %q (Resource Group %q): %+v", workspaceName, resGroup, err)
+		} else {
+			if props := workspace.WorkspaceProperties; props != nil {
+				d.Set("workspace_customer_id", props.CustomerID)
+			}
+
+			// when importing there's no existing value, so use the casing from the Workspace itself
+			if configuredWorkspaceName == "" && workspace.Name != nil {
+				d.Set("workspace_name", workspace.Name)
+			}
+		}
+	}
+
+	d.Set("workspace_id", logAnalyticsWorkspaceID(meta.(*ArmClient).subscriptionId, resGroup, d.Get("workspace_name").(string)))
 
+	if props := resp.LinkedServiceProperties; props != nil {
+		d.Set("resource_id", props.ResourceID)
+	}
+
+	// TODO: remove in 2.0
 	linkedServiceProperties := flattenLogAnalyticsWorkspaceLinkedServiceProperties(resp.LinkedServiceProperties)
 	if err := d.Set("linked_service_properties", linkedServiceProperties); err != nil {
 		return fmt.Errorf("Error setting `linked_service_properties`: %+v", err)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	// Azure can return the tag keys with a different casing to the one specified, so keep the casing from the config
+	configuredTagKeys := make([]string, 0)
+	for k := range d.Get("tags").(map[string]interface{}) {
+		configuredTagKeys = append(configuredTagKeys, k)
+	}
+
+	flattenAndSetTags(d, normalizeTagKeys(resp.Tags, configuredTagKeys...))
 	return nil
 }
 
 func resourceArmLogAnalyticsWorkspaceLinkedServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).linkedServicesClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLogAnalyticsWorkspaceLinkedServiceID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -184,19 +462,1111 @@ func resourceArmLogAnalyticsWorkspaceLinkedServiceDelete(d *schema.ResourceData,
 	workspaceName := id.Path["workspaces"]
 	lsName := id.Path["linkedServices"]
 
+	// lock on the Workspace so that it can't be deleted whilst this Linked Service is being deleted
+	lockName, err := logAnalyticsWorkspaceLinkedServiceLockName(d.Id())
+	if err != nil {
+		return err
+	}
+	azureRMLockByName(lockName, logAnalyticsWorkspaceResourceName)
+	defer azureRMUnlockByName(lockName, logAnalyticsWorkspaceResourceName)
+
+	// removing the Automation link whilst Solutions still depend upon it leaves the Workspace in a broken state
+	if !d.Get("force_destroy").(bool) && strings.EqualFold(lsName, "automation") {
+		solutionsClient := meta.(*ArmClient).solutionsClient
+		solutions, err := solutionsClient.ListByResourceGroup(ctx, resGroup)
+		if err != nil {
+			// the credentials in use may be able to delete Linked Services without being able to list Solutions
+			if !utils.ResponseWasForbidden(solutions.Response) {
+				return fmt.Errorf("Error listing Solutions to check for dependencies on Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+			}
+
+			log.Printf("[WARN] Unable to list Solutions (Resource Group %q) to check for dependencies on Linked Service %q (Workspace %q): %+v", resGroup, lsName, workspaceName, err)
+		} else {
+			workspaceID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s", id.SubscriptionID, resGroup, workspaceName)
+			if names := findLogAnalyticsWorkspaceLinkedServiceDependentSolutions(solutions.Value, workspaceID); len(names) > 0 {
+				return fmt.Errorf("Linked Service %q (Workspace %q / Resource Group %q) can't be deleted since it's used by the Solutions %q - please remove these Solutions first, or set `force_destroy` to `true`", lsName, workspaceName, resGroup, names)
+			}
+		}
+	}
+
 	resp, err := client.Delete(ctx, resGroup, workspaceName, lsName)
+	if err != nil {
+		if !utils.ResponseWasNotFound(resp) {
+			return fmt.Errorf("Error deleting Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Exists"},
+		Target:  []string{"NotFound"},
+		Refresh: logAnalyticsWorkspaceLinkedServiceDeletedRefreshFunc(ctx, client, resGroup, workspaceName, lsName),
+		Timeout: d.Timeout(schema.TimeoutDelete),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Linked Service %q (Workspace %q / Resource Group %q) to be deleted: %+v", lsName, workspaceName, resGroup, err)
+	}
+
+	if d.Get("purge_on_destroy").(bool) {
+		resourceID := expandLogAnalyticsWorkspaceLinkedServiceResourceID(d.Get("resource_id").(string), d.Get("linked_service_properties").([]interface{}))
+
+		list, err := client.ListByWorkspace(ctx, resGroup, workspaceName)
+		if err != nil {
+			// if the Workspace's gone then so are any Linked Services within it
+			if utils.ResponseWasNotFound(list.Response) {
+				return nil
+			}
+
+			return fmt.Errorf("Error listing Linked Services (Workspace %q / Resource Group %q): %+v", workspaceName, resGroup, err)
+		}
+
+		for _, name := range findLogAnalyticsWorkspaceLinkedServicesForResource(list.Value, resourceID) {
+			log.Printf("[WARN] Linked Service %q (Workspace %q / Resource Group %q) is still linked to %q", name, workspaceName, resGroup, resourceID)
+		}
+	}
+
+	return nil
+}
+
+func logAnalyticsWorkspaceLinkedServiceDeletedRefreshFunc(ctx context.Context, client operationalinsights.LinkedServicesClient, resGroup, workspaceName, lsName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		res, err := client.Get(ctx, resGroup, workspaceName, lsName)
+		if err != nil {
+			if utils.ResponseWasNotFound(res.Response) {
+				return "NotFound", "NotFound", nil
+			}
+			return nil, "", fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		}
+
+		// the API can return an empty 200 rather than a 404 once the Linked Service has been deleted
+		if res.ID == nil || *res.ID == "" {
+			return "NotFound", "NotFound", nil
+		}
+
+		return res, "Exists", nil
+	}
+}
+
+func resourceArmLogAnalyticsWorkspaceLinkedServiceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ArmClient).linkedServicesClient
+	ctx := meta.(*ArmClient).StopContext
+
+	// the Linked Service can also be imported using `{resourceGroup}/{workspaceName}/{linkedServiceName}`
+	importID, err := logAnalyticsWorkspaceLinkedServiceImportID(d.Id(), meta.(*ArmClient).subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(importID)
+
+	id, err := parseLogAnalyticsWorkspaceLinkedServiceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	resGroup := id.ResourceGroup
+	workspaceName := id.Path["workspaces"]
+	lsName := id.Path["linkedServices"]
+
+	// confirm the Linked Service exists, rather than importing an ID which will be removed from the state on the next refresh
+	resp, err := client.Get(ctx, resGroup, workspaceName, lsName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return nil, fmt.Errorf("Linked Service %q (Workspace %q / Resource Group %q) was not found", lsName, workspaceName, resGroup)
+		}
+
+		return nil, fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// logAnalyticsWorkspaceLinkedServiceImportID returns the Resource ID to import - which is either the ID itself, or the ID
+// built from the `{resourceGroup}/{workspaceName}/{linkedServiceName}` shorthand
+func logAnalyticsWorkspaceLinkedServiceImportID(input string, subscriptionId string) (string, error) {
+	if strings.HasPrefix(strings.ToLower(input), "/subscriptions/") {
+		return input, nil
+	}
+
+	format := "{resourceGroup}/{workspaceName}/{linkedServiceName}"
+	segments := strings.Split(input, "/")
+	if len(segments) != 3 {
+		return "", fmt.Errorf("Expected the Linked Service to be imported using either its Resource ID or in the format %q but got %q", format, input)
+	}
+
+	resGroup := segments[0]
+	workspaceName := segments[1]
+	lsName := segments[2]
+
+	var result *multierror.Error
+	_, errors := azure.SchemaResourceGroupName().ValidateFunc(resGroup, "resource_group_name")
+	result = multierror.Append(result, errors...)
+	_, errors = validateAzureRmLogAnalyticsWorkspaceName(workspaceName, "workspace_name")
+	result = multierror.Append(result, errors...)
+	_, errors = validateLogAnalyticsWorkspaceLinkedServiceName(lsName, "linked_service_name")
+	result = multierror.Append(result, errors...)
+	if err := result.ErrorOrNil(); err != nil {
+		return "", fmt.Errorf("Error parsing the Linked Service to import %q (in the format %q): %+v", input, format, err)
+	}
+
+	return logAnalyticsWorkspaceLinkedServiceID(subscriptionId, resGroup, workspaceName, lsName), nil
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceWorkspaceSku ensures the Workspace's SKU supports Automation Linked Services,
+// which are used by Solutions such as Update Management that aren't available on the legacy Free and Standalone SKUs
+func validateLogAnalyticsWorkspaceLinkedServiceWorkspaceSku(sku *operationalinsights.Sku) error {
+	if sku == nil {
+		return nil
+	}
+
+	if sku.Name == operationalinsights.Free || sku.Name == operationalinsights.Standalone {
+		return fmt.Errorf("the Workspace uses the %q SKU, which doesn't support Automation Linked Services - please upgrade the Workspace to another SKU (e.g. `PerGB2018`) first", string(sku.Name))
+	}
+
+	return nil
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceReplacement returns an error when an existing Linked Service links to a different
+// Resource than the one specified, unless replacing the existing Linked Service has been opted into
+func validateLogAnalyticsWorkspaceLinkedServiceReplacement(existing operationalinsights.LinkedService, resourceID string, allowReplacement bool) error {
+	if existing.ID == nil || *existing.ID == "" || existing.LinkedServiceProperties == nil || existing.LinkedServiceProperties.ResourceID == nil {
+		return nil
+	}
+
+	existingResourceID := *existing.LinkedServiceProperties.ResourceID
+	if strings.EqualFold(existingResourceID, resourceID) {
+		return nil
+	}
+
+	if allowReplacement {
+		log.Printf("[WARN] Replacing the existing Linked Service %q which links to %q with a link to %q", *existing.ID, existingResourceID, resourceID)
+		return nil
+	}
+
+	return fmt.Errorf("the Workspace already has a Linked Service which links to %q - creating this Linked Service would replace it with a link to %q. Either import the existing Linked Service, or set the Environment Variable `ARM_PROVIDER_REPLACE_EXISTING_LINKED_SERVICES` to `true` to replace it", existingResourceID, resourceID)
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceAutomationAccountSku returns an error when the Automation Account uses the
+// `Free` SKU, which doesn't support Update Management - since this is best-effort, failing to retrieve the account is logged
+func validateLogAnalyticsWorkspaceLinkedServiceAutomationAccountSku(get func() (automation.Account, error)) error {
+	account, err := get()
+	if err != nil {
+		log.Printf("[WARN] Unable to retrieve the Automation Account to check it's SKU: %+v", err)
+		return nil
+	}
+
+	if props := account.AccountProperties; props != nil && props.Sku != nil && props.Sku.Name == automation.Free {
+		return fmt.Errorf("the Automation Account uses the %q SKU, which doesn't support Update Management - please upgrade the Automation Account to the %q SKU, or set the Environment Variable `ARM_PROVIDER_SKIP_LINKED_SERVICE_AUTOMATION_SKU_CHECK` to `true` to skip this check", string(automation.Free), string(automation.Basic))
+	}
+
+	return nil
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceResourceExists returns an error when the Resource to link doesn't exist - other
+// errors are logged rather than returned, since the credentials in use may not be able to read the linked Resource
+func validateLogAnalyticsWorkspaceLinkedServiceResourceExists(resourceID string, get func() (autorest.Response, error)) error {
+	resp, err := get()
 	if err != nil {
 		if utils.ResponseWasNotFound(resp) {
-			return nil
+			return fmt.Errorf("referenced resource %s does not exist", resourceID)
 		}
 
-		return fmt.Errorf("Error deleting Linked Service %q (Workspace %q / Resource Group %q): %+v", lsName, workspaceName, resGroup, err)
+		log.Printf("[WARN] Unable to retrieve the referenced resource %s to check it exists: %+v", resourceID, err)
+	}
+
+	return nil
+}
+
+// verifyLogAnalyticsWorkspaceLinkedServicePermissions confirms the current credentials can write a Linked Service
+// to the Workspace and read the linked Resource, since otherwise the API returns a fairly generic error
+func verifyLogAnalyticsWorkspaceLinkedServicePermissions(ctx context.Context, client authorization.PermissionsClient, resGroup, workspaceName, resourceID string) error {
+	workspacePermissions, err := listLogAnalyticsWorkspaceLinkedServicePermissions(ctx, client, resGroup, "Microsoft.OperationalInsights", "workspaces", workspaceName)
+	if err != nil {
+		return fmt.Errorf("Error listing permissions for Log Analytics Workspace %q (Resource Group %q): %+v", workspaceName, resGroup, err)
+	}
+
+	writeAction := logAnalyticsWorkspaceLinkedServiceWriteAction
+	if !logAnalyticsWorkspaceLinkedServicePermissionsAllow(workspacePermissions, writeAction) {
+		return fmt.Errorf("The credentials in use don't have permission to create Linked Services within Log Analytics Workspace %q (Resource Group %q) - please assign a role granting %q (e.g. `Log Analytics Contributor`) and try again", workspaceName, resGroup, writeAction)
+	}
+
+	id, err := parseAzureResourceID(resourceID)
+	if err != nil {
+		return err
+	}
+
+	accountName := id.Path["automationAccounts"]
+	if accountName == "" {
+		return nil
+	}
+
+	accountPermissions, err := listLogAnalyticsWorkspaceLinkedServicePermissions(ctx, client, id.ResourceGroup, "Microsoft.Automation", "automationAccounts", accountName)
+	if err != nil {
+		return fmt.Errorf("Error listing permissions for Automation Account %q (Resource Group %q): %+v", accountName, id.ResourceGroup, err)
+	}
+
+	readAction := "Microsoft.Automation/automationAccounts/read"
+	if !logAnalyticsWorkspaceLinkedServicePermissionsAllow(accountPermissions, readAction) {
+		return fmt.Errorf("The credentials in use don't have permission to read Automation Account %q (Resource Group %q) - please assign a role granting %q (e.g. `Reader`) and try again", accountName, id.ResourceGroup, readAction)
 	}
 
 	return nil
 }
 
-func flattenLogAnalyticsWorkspaceLinkedServiceProperties(input *operationalinsights.LinkedServiceProperties) interface{} {
+func listLogAnalyticsWorkspaceLinkedServicePermissions(ctx context.Context, client authorization.PermissionsClient, resGroup, providerNamespace, resourceType, name string) ([]authorization.Permission, error) {
+	permissions := make([]authorization.Permission, 0)
+
+	iterator, err := client.ListForResourceComplete(ctx, resGroup, providerNamespace, "", resourceType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for iterator.NotDone() {
+		permissions = append(permissions, iterator.Value())
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return permissions, nil
+}
+
+// logAnalyticsWorkspaceLinkedServicePermissionsAllow returns whether the specified action is allowed by any of the permissions,
+// where actions can contain wildcards (e.g. `Microsoft.OperationalInsights/*`) and `NotActions` take precedence
+func logAnalyticsWorkspaceLinkedServicePermissionsAllow(permissions []authorization.Permission, action string) bool {
+	matches := func(patterns *[]string) bool {
+		if patterns == nil {
+			return false
+		}
+
+		for _, pattern := range *patterns {
+			if logAnalyticsWorkspaceLinkedServiceActionMatches(pattern, action) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, permission := range permissions {
+		if matches(permission.Actions) && !matches(permission.NotActions) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logAnalyticsWorkspaceLinkedServiceActionMatches returns whether the action matches the pattern (case-insensitively),
+// where a `*` within the pattern matches any sequence of characters
+func logAnalyticsWorkspaceLinkedServiceActionMatches(pattern string, action string) bool {
+	segments := strings.Split(strings.ToLower(pattern), "*")
+	remaining := strings.ToLower(action)
+
+	if len(segments) == 1 {
+		return segments[0] == remaining
+	}
+
+	if !strings.HasPrefix(remaining, segments[0]) {
+		return false
+	}
+	remaining = remaining[len(segments[0]):]
+
+	for _, segment := range segments[1 : len(segments)-1] {
+		index := strings.Index(remaining, segment)
+		if index == -1 {
+			return false
+		}
+		remaining = remaining[index+len(segment):]
+	}
+
+	return strings.HasSuffix(remaining, segments[len(segments)-1])
+}
+
+func resourceArmLogAnalyticsWorkspaceLinkedServiceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// values which aren't known yet (e.g. interpolated from another resource) are validated at apply time
+	workspaceName := ""
+	if d.NewValueKnown("workspace_name") {
+		workspaceName = d.Get("workspace_name").(string)
+	}
+
+	resourceID := ""
+	if d.NewValueKnown("resource_id") {
+		resourceID = d.Get("resource_id").(string)
+	}
+	if resourceID == "" && d.NewValueKnown("linked_service_properties") {
+		linkedServiceProperties := d.Get("linked_service_properties").([]interface{})
+		if err := validateLogAnalyticsWorkspaceLinkedServiceProperties(linkedServiceProperties); err != nil {
+			return err
+		}
+
+		resourceID = expandLogAnalyticsWorkspaceLinkedServiceResourceID("", linkedServiceProperties)
+	}
+
+	linkedServiceName := ""
+	if d.NewValueKnown("linked_service_name") {
+		linkedServiceName = d.Get("linked_service_name").(string)
+	}
+
+	tags := make(map[string]interface{})
+	if d.NewValueKnown("tags") {
+		tags = d.Get("tags").(map[string]interface{})
+	}
+
+	if err := validateLogAnalyticsWorkspaceLinkedService(workspaceName, linkedServiceName, resourceID, tags); err != nil {
+		return err
+	}
+
+	// output the payload which will be sent, to help diagnose why Azure might reject it before it's applied
+	payload := operationalinsights.LinkedService{
+		Tags: expandTags(tags),
+		LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+			ResourceID: utils.String(resourceID),
+		},
+	}
+	log.Printf("[DEBUG] Linked Service payload for Workspace %q: %s", workspaceName, logAnalyticsWorkspaceLinkedServiceRedactedPayload(payload))
+
+	if !validateLinkedServicesDuringPlan {
+		return nil
+	}
+
+	resGroup := ""
+	if d.NewValueKnown("resource_group_name") {
+		resGroup = d.Get("resource_group_name").(string)
+	}
+
+	return validateLogAnalyticsWorkspaceLinkedServiceDependencies(meta.(*ArmClient), resGroup, workspaceName, resourceID)
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceDependencies performs read-only checks that the Workspace and the
+// linked Resource exist and can be read by the current credentials, so that problems surface during the plan
+func validateLogAnalyticsWorkspaceLinkedServiceDependencies(client *ArmClient, resGroup, workspaceName, resourceID string) error {
+	ctx := client.StopContext
+	var result *multierror.Error
+
+	if resGroup != "" && workspaceName != "" {
+		workspace, err := client.workspacesClient.Get(ctx, resGroup, workspaceName)
+		if err != nil {
+			if utils.ResponseWasNotFound(workspace.Response) {
+				result = multierror.Append(result, fmt.Errorf("Log Analytics Workspace %q (Resource Group %q) was not found", workspaceName, resGroup))
+			} else {
+				result = multierror.Append(result, fmt.Errorf("Error retrieving Log Analytics Workspace %q (Resource Group %q) - check the credentials have read access: %+v", workspaceName, resGroup, err))
+			}
+		}
+	}
+
+	if resourceID != "" {
+		id, err := parseAzureResourceID(resourceID)
+		if err != nil {
+			return multierror.Append(result, err).ErrorOrNil()
+		}
+
+		if accountName := id.Path["automationAccounts"]; accountName != "" {
+			account, err := client.automationAccountClient.Get(ctx, id.ResourceGroup, accountName)
+			if err != nil {
+				if utils.ResponseWasNotFound(account.Response) {
+					result = multierror.Append(result, fmt.Errorf("Automation Account %q (Resource Group %q) was not found", accountName, id.ResourceGroup))
+				} else {
+					result = multierror.Append(result, fmt.Errorf("Error retrieving Automation Account %q (Resource Group %q) - check the credentials have read access: %+v", accountName, id.ResourceGroup, err))
+				}
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+// logAnalyticsWorkspaceLinkedServiceResourceTypes contains the type of Resource which each kind of Linked Service links to
+var logAnalyticsWorkspaceLinkedServiceResourceTypes = map[string]string{
+	"automation": "Microsoft.Automation/automationAccounts",
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceName validates the `linked_service_name` - Clusters are linked using a
+// `writeAccessResourceId`, which isn't available in the version of the API used by the Provider at this time
+func validateLogAnalyticsWorkspaceLinkedServiceName(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if strings.EqualFold(v, "cluster") {
+		errors = append(errors, fmt.Errorf("%q can't be `cluster` at this time, since linking a Log Analytics Cluster requires a `writeAccessResourceId` which isn't supported by the version of the API used by the Provider", k))
+		return
+	}
+
+	if _, ok := logAnalyticsWorkspaceLinkedServiceResourceTypes[strings.ToLower(v)]; !ok {
+		errors = append(errors, fmt.Errorf("%q must be `automation` but got %q", k, v))
+	}
+
+	return
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceResourceType returns an error when the Resource ID isn't for the type of Resource
+// which the Linked Service supports, e.g. the `automation` Linked Service can only link to an Automation Account
+func validateLogAnalyticsWorkspaceLinkedServiceResourceType(linkedServiceName string, resourceID string) error {
+	resourceType, ok := logAnalyticsWorkspaceLinkedServiceResourceTypes[strings.ToLower(linkedServiceName)]
+	if !ok {
+		return nil
+	}
+
+	if actual := logAnalyticsWorkspaceLinkedServiceResourceType(resourceID); !strings.EqualFold(actual, resourceType) {
+		return fmt.Errorf("`resource_id` must be the ID of a %q Resource when `linked_service_name` is %q but got the ID of a %q Resource (%q)", resourceType, linkedServiceName, actual, resourceID)
+	}
+
+	return nil
+}
+
+// logAnalyticsWorkspaceLinkedServiceResourceType returns the type of the Resource from it's ID (e.g. `Microsoft.Automation/automationAccounts`)
+func logAnalyticsWorkspaceLinkedServiceResourceType(resourceID string) string {
+	id, err := parseAzureResourceID(resourceID)
+	if err != nil || id.Provider == "" {
+		return ""
+	}
+
+	// the type is the last key prior to the name of the Resource
+	segments := strings.Split(strings.Trim(resourceID, "/"), "/")
+	if len(segments) < 2 {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", id.Provider, segments[len(segments)-2])
+}
+
+// logAnalyticsWorkspaceLinkedServiceAutomationAccountName returns the name of the Automation Account from it's ID, or
+// an empty string when the Resource ID is for another type of Resource
+func logAnalyticsWorkspaceLinkedServiceAutomationAccountName(resourceID string) string {
+	if !strings.EqualFold(logAnalyticsWorkspaceLinkedServiceResourceType(resourceID), logAnalyticsWorkspaceLinkedServiceResourceTypes["automation"]) {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(resourceID, "/"), "/")
+	return segments[len(segments)-1]
+}
+
+// validateLogAnalyticsWorkspaceLinkedService validates all of the user-specified fields at once
+// so that every problem is surfaced in a single plan, rather than one per apply
+func validateLogAnalyticsWorkspaceLinkedService(workspaceName string, linkedServiceName string, resourceID string, tags map[string]interface{}) error {
+	var result *multierror.Error
+
+	if workspaceName != "" {
+		_, errors := validateAzureRmLogAnalyticsWorkspaceName(workspaceName, "workspace_name")
+		result = multierror.Append(result, errors...)
+	}
+
+	if linkedServiceName != "" {
+		_, errors := validateLogAnalyticsWorkspaceLinkedServiceName(linkedServiceName, "linked_service_name")
+		result = multierror.Append(result, errors...)
+	}
+
+	if resourceID != "" {
+		_, errors := azure.ValidateResourceID(resourceID, "resource_id")
+		result = multierror.Append(result, errors...)
+
+		if len(errors) == 0 && linkedServiceName != "" {
+			if err := validateLogAnalyticsWorkspaceLinkedServiceResourceType(linkedServiceName, resourceID); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+	}
+
+	_, errors := validateAzureRMTagsWithoutEmptyValues(tags, "tags")
+	result = multierror.Append(result, errors...)
+
+	return result.ErrorOrNil()
+}
+
+// retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled retries the request when it's throttled (429) or fails with a
+// transient server error (5xx), waiting for the duration in the `Retry-After` header when it's returned and otherwise
+// backing off exponentially - until the Context is cancelled (e.g. the timeout is reached). Other errors (such as a 400
+// for a validation error) are returned immediately.
+func retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(ctx context.Context, description string, request func() (autorest.Response, error)) error {
+	for attempt := 1; ; attempt++ {
+		resp, err := request()
+		if err == nil || !logAnalyticsWorkspaceLinkedServiceResponseIsRetryable(resp) {
+			return err
+		}
+
+		wait := logAnalyticsWorkspaceLinkedServiceRetryAfter(resp, attempt)
+		log.Printf("[DEBUG] %s returned the Status Code %d (attempt %d) - retrying in %s", description, resp.StatusCode, attempt, wait)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("giving up after %d attempts: %+v", attempt, err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// logAnalyticsWorkspaceLinkedServiceResponseIsRetryable returns whether the request was throttled or failed with a transient server
+// error - other server errors (e.g. a 501) won't succeed when retried, so these are returned immediately
+func logAnalyticsWorkspaceLinkedServiceResponseIsRetryable(resp autorest.Response) bool {
+	if resp.Response == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// logAnalyticsWorkspaceLinkedServiceRetryAfter returns how long to wait before retrying the request - either the value of the
+// `Retry-After` header (which can be a number of seconds or a HTTP date) or an exponential backoff capped at a minute
+func logAnalyticsWorkspaceLinkedServiceRetryAfter(resp autorest.Response, attempt int) time.Duration {
+	if resp.Response != nil {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+
+			if date, err := http.ParseTime(retryAfter); err == nil {
+				if wait := time.Until(date); wait > 0 {
+					return wait
+				}
+				return 0
+			}
+		}
+	}
+
+	wait := 5 * time.Second
+	for i := 1; i < attempt && wait < time.Minute; i++ {
+		wait *= 2
+	}
+	if wait > time.Minute {
+		wait = time.Minute
+	}
+
+	return wait
+}
+
+// logAnalyticsWorkspaceLinkedServiceServiceError returns the error returned from Azure (which contains the Code, Message and
+// Target) from within the error returned from the SDK - or nil if there isn't one (e.g. the request failed to be sent)
+func logAnalyticsWorkspaceLinkedServiceServiceError(err error) *az.ServiceError {
+	switch e := err.(type) {
+	case autorest.DetailedError:
+		return logAnalyticsWorkspaceLinkedServiceServiceError(e.Original)
+	case *autorest.DetailedError:
+		return logAnalyticsWorkspaceLinkedServiceServiceError(e.Original)
+	case az.RequestError:
+		return e.ServiceError
+	case *az.RequestError:
+		return e.ServiceError
+	}
+
+	return nil
+}
+
+const (
+	logAnalyticsWorkspaceLinkedServiceReadAction  = "Microsoft.OperationalInsights/workspaces/linkedServices/read"
+	logAnalyticsWorkspaceLinkedServiceWriteAction = "Microsoft.OperationalInsights/workspaces/linkedServices/write"
+)
+
+// logAnalyticsWorkspaceLinkedServiceForbiddenError returns an error explaining which permission is missing, so that it's
+// clear whether the credentials in use can't read the Linked Service (e.g. during a plan) or can't write it (during an apply)
+func logAnalyticsWorkspaceLinkedServiceForbiddenError(action, lsName, workspaceName, resGroup string, err error) error {
+	operation := "read"
+	role := "Log Analytics Reader"
+	if action == logAnalyticsWorkspaceLinkedServiceWriteAction {
+		operation = "write"
+		role = "Log Analytics Contributor"
+	}
+
+	return fmt.Errorf("The credentials in use don't have permission to %s Linked Service %q (Workspace %q / Resource Group %q) - please assign a role granting %q (e.g. `%s`) and try again: %+v", operation, lsName, workspaceName, resGroup, action, role, err)
+}
+
+// getLogAnalyticsWorkspaceLinkedServiceForRead retrieves the Linked Service so that it can be read into the state, returning
+// nil when either the Linked Service or the Workspace it belongs to no longer exists
+func getLogAnalyticsWorkspaceLinkedServiceForRead(get func() (operationalinsights.LinkedService, error), list func() (operationalinsights.LinkedServiceListResult, error), getWorkspace func() (operationalinsights.Workspace, error), resGroup, workspaceName, lsName string) (*operationalinsights.LinkedService, error) {
+	resp, err := get()
+	if err != nil {
+		if utils.ResponseWasForbidden(resp.Response) {
+			return nil, logAnalyticsWorkspaceLinkedServiceForbiddenError(logAnalyticsWorkspaceLinkedServiceReadAction, lsName, workspaceName, resGroup, err)
+		}
+
+		if !utils.ResponseWasNotFound(resp.Response) {
+			// when the Workspace has been deleted out-of-band the API doesn't always return a 404 for the Linked Service,
+			// so check if the Workspace still exists before returning the error
+			workspace, workspaceErr := getWorkspace()
+			if workspaceErr != nil && utils.ResponseWasNotFound(workspace.Response) {
+				log.Printf("[WARN] Log Analytics Workspace %q (Resource Group %q) no longer exists - removing Linked Service %q from state", workspaceName, resGroup, lsName)
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("Error making Read request on AzureRM Log Analytics Linked Service '%s': %+v", lsName, err)
+		}
+	}
+
+	statusCode := 0
+	if resp.Response.Response != nil {
+		statusCode = resp.StatusCode
+	}
+
+	// a successful response without an ID is malformed, so rather than removing the Linked Service from the state this is
+	// surfaced - only a 404 means that it's gone
+	if err == nil && (resp.ID == nil || *resp.ID == "") {
+		return nil, fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): the API returned an unexpected response without an ID (Status Code %d)", lsName, workspaceName, resGroup, statusCode)
+	}
+
+	// the Get can transiently 404 shortly after creation, so confirm it's gone using the List before removing it from the
+	// state, rather than trusting a single response
+	if err != nil {
+		linkedServices, err := list()
+		if err != nil {
+			if utils.ResponseWasNotFound(linkedServices.Response) {
+				log.Printf("[WARN] Log Analytics Workspace %q (Resource Group %q) no longer exists - removing Linked Service %q from state", workspaceName, resGroup, lsName)
+				return nil, nil
+			}
+			if utils.ResponseWasForbidden(linkedServices.Response) {
+				return nil, logAnalyticsWorkspaceLinkedServiceForbiddenError(logAnalyticsWorkspaceLinkedServiceReadAction, lsName, workspaceName, resGroup, err)
+			}
+			return nil, fmt.Errorf("Error listing Linked Services (Workspace %q / Resource Group %q): %+v", workspaceName, resGroup, err)
+		}
+
+		linkedService := findLogAnalyticsWorkspaceLinkedService(linkedServices.Value, lsName)
+		if linkedService == nil {
+			log.Printf("[DEBUG] Linked Service %q was not found in Workspace %q / Resource Group %q - removing from state", lsName, workspaceName, resGroup)
+			return nil, nil
+		}
+
+		if linkedService.ID == nil || *linkedService.ID == "" {
+			return nil, fmt.Errorf("Error retrieving Linked Service %q (Workspace %q / Resource Group %q): the API returned an unexpected response without an ID (Status Code %d)", lsName, workspaceName, resGroup, statusCode)
+		}
+
+		resp = *linkedService
+	}
+
+	return &resp, nil
+}
+
+// getLogAnalyticsWorkspaceLinkedServiceFallingBackToList retrieves the Linked Service using the Get - which can 404 for a short
+// period after the Linked Service has been created - falling back to finding it in the List, which tends to be updated sooner
+func getLogAnalyticsWorkspaceLinkedServiceFallingBackToList(get func() (operationalinsights.LinkedService, error), list func() (operationalinsights.LinkedServiceListResult, error), linkedServiceName string) (operationalinsights.LinkedService, bool, error) {
+	resp, err := get()
+	if err == nil {
+		return resp, resp.ID != nil, nil
+	}
+	if !utils.ResponseWasNotFound(resp.Response) {
+		return resp, false, err
+	}
+
+	linkedServices, err := list()
+	if err != nil {
+		return resp, false, fmt.Errorf("Error listing Linked Services: %+v", err)
+	}
+
+	if linkedService := findLogAnalyticsWorkspaceLinkedService(linkedServices.Value, linkedServiceName); linkedService != nil && linkedService.ID != nil {
+		return *linkedService, true, nil
+	}
+
+	return resp, false, nil
+}
+
+func waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(ctx context.Context, get func() (operationalinsights.LinkedService, error), list func() (operationalinsights.LinkedServiceListResult, error), linkedServiceName string, timeout time.Duration) (operationalinsights.LinkedService, error) {
+	lastStatusCode := 0
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"NotFound"},
+		Target:  []string{"Found"},
+		Refresh: logAnalyticsWorkspaceLinkedServiceVisibleRefreshFunc(ctx, get, list, linkedServiceName, &lastStatusCode),
+		Timeout: timeout,
+	}
+
+	resp, err := stateConf.WaitForState()
+	if err != nil {
+		if _, ok := err.(*resource.TimeoutError); ok {
+			return operationalinsights.LinkedService{}, fmt.Errorf("Linked Service %q wasn't found within %s (the last response had the Status Code %d): %+v", linkedServiceName, timeout, lastStatusCode, err)
+		}
+
+		return operationalinsights.LinkedService{}, err
+	}
+
+	return resp.(operationalinsights.LinkedService), nil
+}
+
+func logAnalyticsWorkspaceLinkedServiceVisibleRefreshFunc(ctx context.Context, get func() (operationalinsights.LinkedService, error), list func() (operationalinsights.LinkedServiceListResult, error), linkedServiceName string, lastStatusCode *int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		// stop polling as soon as Terraform has been asked to stop, rather than waiting for the timeout
+		if err := ctx.Err(); err != nil {
+			return nil, "", fmt.Errorf("Stopped waiting for Linked Service %q: %+v", linkedServiceName, err)
+		}
+
+		resp, found, err := getLogAnalyticsWorkspaceLinkedServiceFallingBackToList(get, list, linkedServiceName)
+		if r := resp.Response.Response; r != nil {
+			*lastStatusCode = r.StatusCode
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		if !found {
+			log.Printf("[DEBUG] Linked Service %q isn't visible yet - still provisioning", linkedServiceName)
+			return resp, "NotFound", nil
+		}
+
+		return resp, "Found", nil
+	}
+}
+
+// waitForLogAnalyticsWorkspaceLinkedServiceTags waits for the tags to match for the remainder of the Context's deadline
+// (e.g. the create/update timeout) - falling back to 2 minutes when the Context doesn't have a deadline
+func waitForLogAnalyticsWorkspaceLinkedServiceTags(ctx context.Context, get func() (operationalinsights.LinkedService, error), expected map[string]*string) (operationalinsights.LinkedService, error) {
+	var linkedService operationalinsights.LinkedService
+	var getErr error
+
+	timeout := 2 * time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		// stop waiting as soon as Terraform has been asked to stop (or the timeout's been reached)
+		if err := ctx.Err(); err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Stopped waiting for the Tags to propagate: %+v", err))
+		}
+
+		resp, err := get()
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return resource.NonRetryableError(fmt.Errorf("Stopped waiting for the Tags to propagate: %+v", ctxErr))
+			}
+
+			getErr = err
+			return resource.NonRetryableError(err)
+		}
+
+		linkedService = resp
+		if !logAnalyticsWorkspaceLinkedServiceTagsMatch(expected, resp.Tags) {
+			return resource.RetryableError(fmt.Errorf("Expected the Tags %+v but got %+v", expected, resp.Tags))
+		}
+
+		return nil
+	})
+	if err != nil {
+		if getErr != nil {
+			return linkedService, getErr
+		}
+
+		// this is best-effort, the next refresh will pick up the tags once they've propagated
+		log.Printf("[WARN] Tags for Linked Service haven't propagated after %s: %+v", timeout, err)
+	}
+
+	return linkedService, nil
+}
+
+func logAnalyticsWorkspaceLinkedServiceTagsMatch(expected map[string]*string, actual map[string]*string) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	expectedKeys := make([]string, 0, len(expected))
+	for k := range expected {
+		expectedKeys = append(expectedKeys, k)
+	}
+	actual = normalizeTagKeys(actual, expectedKeys...)
+
+	for k, v := range expected {
+		actualValue, ok := actual[k]
+		if !ok {
+			return false
+		}
+
+		if v == nil || actualValue == nil {
+			if v != actualValue {
+				return false
+			}
+			continue
+		}
+
+		if *v != *actualValue {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress suppresses differences in the casing of the Subscription ID
+// and Provider Namespace within `resource_id`, since the API returns these in lower-case
+func logAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeLogAnalyticsWorkspaceLinkedServiceResourceID(old) == normalizeLogAnalyticsWorkspaceLinkedServiceResourceID(new)
+}
+
+func normalizeLogAnalyticsWorkspaceLinkedServiceResourceID(input string) string {
+	segments := strings.Split(input, "/")
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "subscriptions") || strings.EqualFold(segment, "providers") {
+			segments[i] = strings.ToLower(segment)
+
+			if i+1 < len(segments) {
+				segments[i+1] = strings.ToLower(segments[i+1])
+			}
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// parseLogAnalyticsWorkspaceLinkedServiceID parses a Linked Service ID, ensuring it's in the format
+// `.../workspaces/{workspaceName}/linkedServices/{linkedServiceName}` - the keys within the ID are matched
+// case-insensitively (since IDs copied from the Portal or stored in legacy state can use a different casing)
+// and the returned ID uses the canonical casing for each key
+func parseLogAnalyticsWorkspaceLinkedServiceID(input string) (*ResourceID, error) {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Linked Service ID %q: %+v", input, err)
+	}
+
+	provider := id.Provider
+	if provider == "" {
+		provider = azure.ResourceIDPathValue(id.Path, "providers")
+	}
+	if !strings.EqualFold(provider, "Microsoft.OperationalInsights") {
+		return nil, fmt.Errorf("Expected the Linked Service ID %q to be for the provider `Microsoft.OperationalInsights` but got %q", input, provider)
+	}
+
+	workspaceName := azure.ResourceIDPathValue(id.Path, "workspaces")
+	linkedServiceName := azure.ResourceIDPathValue(id.Path, "linkedServices")
+	expectedKeys := 2
+	if id.Provider == "" {
+		expectedKeys = 3
+	}
+	if workspaceName == "" || linkedServiceName == "" || len(id.Path) != expectedKeys {
+		return nil, fmt.Errorf("Expected the Linked Service ID %q to be in the format `.../workspaces/{workspaceName}/linkedServices/{linkedServiceName}`", input)
+	}
+
+	return &ResourceID{
+		SubscriptionID: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+		Provider:       "Microsoft.OperationalInsights",
+		Path: map[string]string{
+			"workspaces":     workspaceName,
+			"linkedServices": linkedServiceName,
+		},
+	}, nil
+}
+
+// logAnalyticsWorkspaceLinkedServiceResourceGroupName returns the name of the Resource Group using the casing from the
+// ID returned by Azure, providing it only differs by case - otherwise the specified Resource Group name is returned
+func logAnalyticsWorkspaceLinkedServiceResourceGroupName(resourceGroup string, resourceID string) string {
+	id, err := parseAzureResourceID(resourceID)
+	if err != nil || !strings.EqualFold(id.ResourceGroup, resourceGroup) {
+		return resourceGroup
+	}
+
+	return id.ResourceGroup
+}
+
+// logAnalyticsWorkspaceLinkedServiceLockName returns the key used to lock the Workspace which the Linked Service belongs to
+func logAnalyticsWorkspaceLinkedServiceLockName(input string) (string, error) {
+	id, err := parseLogAnalyticsWorkspaceLinkedServiceID(input)
+	if err != nil {
+		return "", err
+	}
+
+	return logAnalyticsWorkspaceLockName(id.SubscriptionID, id.ResourceGroup, id.Path["workspaces"]), nil
+}
+
+// logAnalyticsWorkspaceLinkedServiceID returns the canonical form of the Resource ID for a Linked Service
+func logAnalyticsWorkspaceLinkedServiceID(subscriptionId, resourceGroup, workspaceName, linkedServiceName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s/linkedServices/%s", subscriptionId, resourceGroup, workspaceName, linkedServiceName)
+}
+
+// findLogAnalyticsWorkspaceLinkedService returns the Linked Service with the specified name from a list of
+// Linked Services, where the API returns the name in the format `{workspaceName}/{linkedServiceName}`
+func findLogAnalyticsWorkspaceLinkedService(input *[]operationalinsights.LinkedService, linkedServiceName string) *operationalinsights.LinkedService {
+	if input == nil {
+		return nil
+	}
+
+	for _, v := range *input {
+		if v.Name == nil {
+			continue
+		}
+
+		segments := strings.Split(*v.Name, "/")
+		if strings.EqualFold(segments[len(segments)-1], linkedServiceName) {
+			linkedService := v
+			return &linkedService
+		}
+	}
+
+	return nil
+}
+
+// findLogAnalyticsWorkspaceLinkedServicesForResource returns the names of any Linked Services which link to the specified Resource
+func findLogAnalyticsWorkspaceLinkedServicesForResource(input *[]operationalinsights.LinkedService, resourceID string) []string {
+	names := make([]string, 0)
+	if input == nil || resourceID == "" {
+		return names
+	}
+
+	for _, v := range *input {
+		if v.Name == nil || v.LinkedServiceProperties == nil || v.LinkedServiceProperties.ResourceID == nil {
+			continue
+		}
+
+		if strings.EqualFold(*v.LinkedServiceProperties.ResourceID, resourceID) {
+			names = append(names, *v.Name)
+		}
+	}
+
+	return names
+}
+
+func expandLogAnalyticsWorkspaceLinkedServiceProperties(input []interface{}) map[string]interface{} {
+	if len(input) == 0 || input[0] == nil {
+		return map[string]interface{}{
+			"resource_id": "",
+		}
+	}
+
+	properties, ok := input[0].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{
+			"resource_id": "",
+		}
+	}
+
+	return properties
+}
+
+// validateLogAnalyticsWorkspaceLinkedServiceProperties ensures that when the deprecated `linked_service_properties`
+// block is specified it contains a `resource_id`, rather than an empty block being treated as if it were omitted
+func validateLogAnalyticsWorkspaceLinkedServiceProperties(input []interface{}) error {
+	if len(input) == 0 {
+		return nil
+	}
+
+	properties, ok := input[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("`linked_service_properties.0.resource_id` must be specified when `linked_service_properties` is set")
+	}
+
+	if v, _ := properties["resource_id"].(string); v == "" {
+		return fmt.Errorf("`linked_service_properties.0.resource_id` must be specified when `linked_service_properties` is set")
+	}
+
+	return nil
+}
+
+// logAnalyticsWorkspaceLinkedServicePreserveCasing returns the existing value when it only differs by case from
+// the one in the Resource ID, so that the casing specified by the user is preserved
+func logAnalyticsWorkspaceLinkedServicePreserveCasing(existing string, fromID string) string {
+	if strings.EqualFold(existing, fromID) {
+		return existing
+	}
+
+	return fromID
+}
+
+// mergeLogAnalyticsWorkspaceLinkedServiceProperties returns the existing Linked Service Properties, only replacing the
+// Resource ID when it's changing (or isn't returned from the API) - so that the linked Resource isn't unintentionally changed
+func mergeLogAnalyticsWorkspaceLinkedServiceProperties(existing *operationalinsights.LinkedServiceProperties, resourceID string, resourceIDChanged bool) *operationalinsights.LinkedServiceProperties {
+	output := operationalinsights.LinkedServiceProperties{}
+	if existing != nil {
+		output = *existing
+	}
+
+	if resourceID == "" {
+		return &output
+	}
+
+	if resourceIDChanged || output.ResourceID == nil || *output.ResourceID == "" {
+		output.ResourceID = utils.String(resourceID)
+	}
+
+	return &output
+}
+
+// expandLogAnalyticsWorkspaceLinkedServiceResourceID returns the ID of the Resource to link, which can be specified
+// either via the top-level `resource_id` or the deprecated `linked_service_properties` block
+func expandLogAnalyticsWorkspaceLinkedServiceResourceID(resourceID string, linkedServiceProperties []interface{}) string {
+	if resourceID != "" {
+		return resourceID
+	}
+
+	// TODO: remove in 2.0
+	properties := expandLogAnalyticsWorkspaceLinkedServiceProperties(linkedServiceProperties)
+	v, _ := properties["resource_id"].(string)
+	return v
+}
+
+// findLogAnalyticsWorkspaceLinkedServiceDependentSolutions returns the names of any Solutions within the specified Workspace
+// which depend on the Automation Linked Service, e.g. `Updates(workspace1)`
+func findLogAnalyticsWorkspaceLinkedServiceDependentSolutions(input *[]operationsmanagement.Solution, workspaceID string) []string {
+	names := make([]string, 0)
+	if input == nil {
+		return names
+	}
+
+	dependentSolutions := []string{
+		"ChangeTracking",
+		"Updates",
+	}
+
+	for _, v := range *input {
+		if v.Name == nil || v.Properties == nil || v.Properties.WorkspaceResourceID == nil {
+			continue
+		}
+
+		if !strings.EqualFold(*v.Properties.WorkspaceResourceID, workspaceID) {
+			continue
+		}
+
+		// Solutions are named in the format `{solutionType}({workspaceName})`
+		solutionType := strings.Split(*v.Name, "(")[0]
+		for _, dependent := range dependentSolutions {
+			if strings.EqualFold(solutionType, dependent) {
+				names = append(names, *v.Name)
+			}
+		}
+	}
+
+	return names
+}
+
+// logAnalyticsWorkspaceLinkedServiceRedactedPayload returns the JSON which would be sent to the API for the specified
+// Linked Service, with the values of any tags redacted since these can contain sensitive information
+func logAnalyticsWorkspaceLinkedServiceRedactedPayload(input operationalinsights.LinkedService) string {
+	redacted := input
+	if input.Tags != nil {
+		redacted.Tags = make(map[string]*string, len(input.Tags))
+		for k := range input.Tags {
+			redacted.Tags[k] = utils.String("REDACTED")
+		}
+	}
+
+	payload, err := json.Marshal(redacted)
+	if err != nil {
+		return fmt.Sprintf("(unable to serialize the payload: %+v)", err)
+	}
+
+	return string(payload)
+}
+
+// logAnalyticsWorkspaceLinkedServiceKind returns the kind of Linked Service based on the type of the linked Resource - at this
+// time the API only supports linking via a read-access Resource ID (e.g. an Automation Account)
+func logAnalyticsWorkspaceLinkedServiceKind(input *operationalinsights.LinkedServiceProperties) string {
+	if input == nil || input.ResourceID == nil || *input.ResourceID == "" {
+		return ""
+	}
+
+	resourceType := logAnalyticsWorkspaceLinkedServiceResourceType(*input.ResourceID)
+	for kind, v := range logAnalyticsWorkspaceLinkedServiceResourceTypes {
+		if strings.EqualFold(v, resourceType) {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+func flattenLogAnalyticsWorkspaceLinkedServiceProperties(input *operationalinsights.LinkedServiceProperties) []interface{} {
 	if input == nil {
 		return []interface{}{}
 	}
@@ -205,8 +1575,8 @@ func flattenLogAnalyticsWorkspaceLinkedServiceProperties(input *operationalinsig
 
 	// resource id linked service
 	if resourceID := input.ResourceID; resourceID != nil {
-		properties["resource_id"] = interface{}(*resourceID)
+		properties["resource_id"] = *resourceID
 	}
 
-	return interface{}(properties)
+	return []interface{}{properties}
 }
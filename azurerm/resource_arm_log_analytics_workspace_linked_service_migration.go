@@ -0,0 +1,73 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func resourceAzureRMLogAnalyticsWorkspaceLinkedServiceMigrateState(v int, is *terraform.InstanceState, _ interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found AzureRM Log Analytics Workspace Linked Service State v0; migrating to v1")
+		var err error
+		if is, err = migrateAzureRMLogAnalyticsWorkspaceLinkedServiceStateV0toV1(is); err != nil {
+			return is, err
+		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found AzureRM Log Analytics Workspace Linked Service State v1; migrating to v2")
+		return migrateAzureRMLogAnalyticsWorkspaceLinkedServiceStateV1toV2(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+func migrateAzureRMLogAnalyticsWorkspaceLinkedServiceStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] ARM Log Analytics Workspace Linked Service Attributes before Migration: %#v", is.Attributes)
+
+	// `linked_service_properties` has changed from a Map to a List containing a single element
+	if _, ok := is.Attributes["linked_service_properties.%"]; ok {
+		resourceID, hasResourceID := is.Attributes["linked_service_properties.resource_id"]
+
+		delete(is.Attributes, "linked_service_properties.%")
+		delete(is.Attributes, "linked_service_properties.resource_id")
+
+		if hasResourceID {
+			is.Attributes["linked_service_properties.#"] = "1"
+			is.Attributes["linked_service_properties.0.resource_id"] = resourceID
+		} else {
+			is.Attributes["linked_service_properties.#"] = "0"
+		}
+	}
+
+	log.Printf("[DEBUG] ARM Log Analytics Workspace Linked Service Attributes after State Migration: %#v", is.Attributes)
+
+	return is, nil
+}
+
+func migrateAzureRMLogAnalyticsWorkspaceLinkedServiceStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] ARM Log Analytics Workspace Linked Service Attributes before Migration: %#v", is.Attributes)
+
+	// `resource_id` has been moved from the `linked_service_properties` block to the top level
+	if resourceID, ok := is.Attributes["linked_service_properties.0.resource_id"]; ok {
+		if _, exists := is.Attributes["resource_id"]; !exists {
+			is.Attributes["resource_id"] = resourceID
+		}
+	}
+
+	log.Printf("[DEBUG] ARM Log Analytics Workspace Linked Service Attributes after State Migration: %#v", is.Attributes)
+
+	return is, nil
+}
@@ -0,0 +1,82 @@
+package azurerm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAzureRMLogAnalyticsWorkspaceLinkedServiceMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		StateVersion int
+		ID           string
+		Attributes   map[string]string
+		Expected     map[string]string
+	}{
+		"v0_1_empty": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes:   map[string]string{},
+			Expected:     map[string]string{},
+		},
+		"v0_1_resource_id": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"name":                                  "workspace1/Automation",
+				"linked_service_name":                   "automation",
+				"linked_service_properties.%":           "1",
+				"linked_service_properties.resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			},
+			Expected: map[string]string{
+				"name":                        "workspace1/Automation",
+				"linked_service_name":         "automation",
+				"linked_service_properties.#": "1",
+				"linked_service_properties.0.resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+				"resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			},
+		},
+		"v0_1_no_properties": {
+			StateVersion: 0,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"linked_service_properties.%": "0",
+			},
+			Expected: map[string]string{
+				"linked_service_properties.#": "0",
+			},
+		},
+		"v1_2_resource_id": {
+			StateVersion: 1,
+			ID:           "some_id",
+			Attributes: map[string]string{
+				"linked_service_name":                     "automation",
+				"linked_service_properties.#":             "1",
+				"linked_service_properties.0.resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			},
+			Expected: map[string]string{
+				"linked_service_name":                     "automation",
+				"linked_service_properties.#":             "1",
+				"linked_service_properties.0.resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+				"resource_id":                             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         tc.ID,
+			Attributes: tc.Attributes,
+		}
+		is, err := resourceAzureRMLogAnalyticsWorkspaceLinkedServiceMigrateState(tc.StateVersion, is, nil)
+
+		if err != nil {
+			t.Fatalf("bad: %q, err: %#v", tn, err)
+		}
+
+		if !reflect.DeepEqual(tc.Expected, is.Attributes) {
+			t.Fatalf("Bad Log Analytics Workspace Linked Service Migrate\n\n. Got: %+v\n\n expected: %+v", is.Attributes, tc.Expected)
+		}
+	}
+}
@@ -1,15 +1,2029 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/automation/mgmt/2015-10-31/automation"
+	"github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-01-01-preview/authorization"
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationalinsights/mgmt/2015-11-01-preview/operationalinsights"
+	"github.com/Azure/azure-sdk-for-go/services/preview/operationsmanagement/mgmt/2015-11-01-preview/operationsmanagement"
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestValidateLogAnalyticsWorkspaceLinkedService(t *testing.T) {
+	tooManyTags := make(map[string]interface{})
+	for i := 0; i < 51; i++ {
+		tooManyTags[fmt.Sprintf("tag%d", i)] = "value"
+	}
+
+	cases := []struct {
+		WorkspaceName     string
+		LinkedServiceName string
+		ResourceID        string
+		Tags              map[string]interface{}
+		ErrCount          int
+	}{
+		{
+			WorkspaceName:     "workspace1",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Tags:              map[string]interface{}{},
+			ErrCount:          0,
+		},
+		{
+			// linking a Cluster requires a `writeAccessResourceId`, which isn't supported by this API version
+			WorkspaceName:     "workspace1",
+			LinkedServiceName: "Cluster",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1",
+			Tags:              map[string]interface{}{},
+			ErrCount:          1,
+		},
+		{
+			WorkspaceName:     "workspace1",
+			LinkedServiceName: "storage",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Tags:              map[string]interface{}{},
+			ErrCount:          1,
+		},
+		{
+			WorkspaceName:     "workspace1",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			Tags:              map[string]interface{}{},
+			ErrCount:          1,
+		},
+		{
+			// Azure drops tags with an empty value
+			WorkspaceName:     "workspace1",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Tags:              map[string]interface{}{"foo": ""},
+			ErrCount:          1,
+		},
+		{
+			// unknown values are skipped
+			WorkspaceName: "",
+			ResourceID:    "",
+			Tags:          map[string]interface{}{},
+			ErrCount:      0,
+		},
+		{
+			WorkspaceName: "-workspace1",
+			ResourceID:    "",
+			Tags:          map[string]interface{}{},
+			ErrCount:      1,
+		},
+		{
+			WorkspaceName: "-workspace1",
+			ResourceID:    "not-a-resource-id",
+			Tags:          tooManyTags,
+			ErrCount:      3,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateLogAnalyticsWorkspaceLinkedService(tc.WorkspaceName, tc.LinkedServiceName, tc.ResourceID, tc.Tags)
+
+		errCount := 0
+		if err != nil {
+			errCount = len(err.(*multierror.Error).Errors)
+		}
+
+		if errCount != tc.ErrCount {
+			t.Fatalf("Expected %d errors for Workspace %q but got %d: %+v", tc.ErrCount, tc.WorkspaceName, errCount, err)
+		}
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceResourceType(t *testing.T) {
+	cases := []struct {
+		Name              string
+		LinkedServiceName string
+		ResourceID        string
+		ShouldError       bool
+	}{
+		{
+			Name:              "automation account",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			ShouldError:       false,
+		},
+		{
+			Name:              "automation account with a different casing",
+			LinkedServiceName: "Automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/microsoft.automation/AutomationAccounts/account1",
+			ShouldError:       false,
+		},
+		{
+			Name:              "storage account for automation",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			ShouldError:       true,
+		},
+		{
+			Name:              "cluster for automation",
+			LinkedServiceName: "automation",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1",
+			ShouldError:       true,
+		},
+		{
+			// other Linked Services may support other types of Resource
+			Name:              "unknown linked service",
+			LinkedServiceName: "other",
+			ResourceID:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			ShouldError:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateLogAnalyticsWorkspaceLinkedServiceResourceType(tc.LinkedServiceName, tc.ResourceID)
+			if (err != nil) != tc.ShouldError {
+				t.Fatalf("Expected an error %t but got %+v", tc.ShouldError, err)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), "Microsoft.Automation/automationAccounts") {
+				t.Fatalf("Expected the error to contain the expected type of Resource but got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "automation",
+			ErrCount: 0,
+		},
+		{
+			Value:    "Automation",
+			ErrCount: 0,
+		},
+		{
+			// linking a Cluster requires a `writeAccessResourceId`, which isn't supported by this API version
+			Value:    "cluster",
+			ErrCount: 1,
+		},
+		{
+			Value:    "storage",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateLogAnalyticsWorkspaceLinkedServiceName(tc.Value, "linked_service_name")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected the Linked Service Name %q to trigger %d validation errors but got %d: %+v", tc.Value, tc.ErrCount, len(errors), errors)
+		}
+	}
+}
+
+func TestFindLogAnalyticsWorkspaceLinkedService(t *testing.T) {
+	linkedServices := []operationalinsights.LinkedService{
+		{
+			Name: utils.String("workspace1/Automation"),
+		},
+		{
+			Name: nil,
+		},
+	}
+
+	cases := []struct {
+		Input             *[]operationalinsights.LinkedService
+		LinkedServiceName string
+		Found             bool
+	}{
+		{
+			// the Get 404's and the List hasn't returned anything yet
+			Input:             nil,
+			LinkedServiceName: "automation",
+			Found:             false,
+		},
+		{
+			// the Get 404's but the List has it
+			Input:             &linkedServices,
+			LinkedServiceName: "automation",
+			Found:             true,
+		},
+		{
+			Input:             &linkedServices,
+			LinkedServiceName: "cluster",
+			Found:             false,
+		},
+	}
+
+	for _, tc := range cases {
+		result := findLogAnalyticsWorkspaceLinkedService(tc.Input, tc.LinkedServiceName)
+		if found := result != nil; found != tc.Found {
+			t.Fatalf("Expected Linked Service %q to be found %t but got %t", tc.LinkedServiceName, tc.Found, found)
+		}
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceDeleteBlocksWorkspaceDelete(t *testing.T) {
+	// the Workspace delete has to wait for the Linked Service delete, so both need to lock using the same key - even when
+	// the IDs use a different casing (e.g. an ID imported from the Portal)
+	cases := []struct {
+		WorkspaceID     string
+		LinkedServiceID string
+	}{
+		{
+			WorkspaceID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-locking",
+			LinkedServiceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-locking/linkedServices/automation",
+		},
+		{
+			WorkspaceID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-locking",
+			LinkedServiceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/ACCTESTRG-LOCKING/providers/microsoft.operationalinsights/Workspaces/ACCTESTLAW-LOCKING/LinkedServices/Automation",
+		},
+	}
+
+	for _, tc := range cases {
+		workspaceLockName, err := logAnalyticsWorkspaceLockNameFromID(tc.WorkspaceID)
+		if err != nil {
+			t.Fatalf("Error determining the lock name for Workspace %q: %+v", tc.WorkspaceID, err)
+		}
+
+		linkedServiceLockName, err := logAnalyticsWorkspaceLinkedServiceLockName(tc.LinkedServiceID)
+		if err != nil {
+			t.Fatalf("Error determining the lock name for Linked Service %q: %+v", tc.LinkedServiceID, err)
+		}
+
+		if workspaceLockName != linkedServiceLockName {
+			t.Fatalf("Expected the Workspace and the Linked Service to use the same lock but got %q and %q", workspaceLockName, linkedServiceLockName)
+		}
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceLocksPerWorkspace(t *testing.T) {
+	cases := []struct {
+		Name           string
+		First          string
+		Second         string
+		ExpectSameLock bool
+	}{
+		{
+			Name:           "same Workspace",
+			First:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-first/linkedServices/automation",
+			Second:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-first/linkedServices/cluster",
+			ExpectSameLock: true,
+		},
+		{
+			Name:           "same Workspace with different casing",
+			First:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-first/linkedServices/automation",
+			Second:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/ACCTESTRG-LOCKING/providers/microsoft.operationalinsights/Workspaces/ACCTESTLAW-FIRST/LinkedServices/Automation",
+			ExpectSameLock: true,
+		},
+		{
+			Name:           "different Workspaces",
+			First:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-first/linkedServices/automation",
+			Second:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-locking/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-second/linkedServices/automation",
+			ExpectSameLock: false,
+		},
+		{
+			Name:           "same Workspace name in different Resource Groups",
+			First:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-first/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-locking/linkedServices/automation",
+			Second:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-second/providers/Microsoft.OperationalInsights/workspaces/acctestlaw-locking/linkedServices/automation",
+			ExpectSameLock: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			first, err := logAnalyticsWorkspaceLinkedServiceLockName(tc.First)
+			if err != nil {
+				t.Fatalf("Error determining the lock name for %q: %+v", tc.First, err)
+			}
+
+			second, err := logAnalyticsWorkspaceLinkedServiceLockName(tc.Second)
+			if err != nil {
+				t.Fatalf("Error determining the lock name for %q: %+v", tc.Second, err)
+			}
+
+			if sameLock := first == second; sameLock != tc.ExpectSameLock {
+				t.Fatalf("Expected the Linked Services to use the same lock to be %t but got %q and %q", tc.ExpectSameLock, first, second)
+			}
+		})
+	}
+}
+
+func TestFindLogAnalyticsWorkspaceLinkedServicesForResource(t *testing.T) {
+	automationAccountID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"
+	linkedServices := []operationalinsights.LinkedService{
+		{
+			Name: utils.String("workspace1/Automation"),
+			LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String(strings.ToLower(automationAccountID)),
+			},
+		},
+		{
+			Name: utils.String("workspace1/Other"),
+			LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account2"),
+			},
+		},
+		{
+			Name: utils.String("workspace1/Empty"),
+		},
+	}
+
+	if names := findLogAnalyticsWorkspaceLinkedServicesForResource(&linkedServices, automationAccountID); len(names) != 1 || names[0] != "workspace1/Automation" {
+		t.Fatalf("Expected only `workspace1/Automation` to be linked to %q but got %+v", automationAccountID, names)
+	}
+
+	if names := findLogAnalyticsWorkspaceLinkedServicesForResource(nil, automationAccountID); len(names) != 0 {
+		t.Fatalf("Expected no Linked Services but got %+v", names)
+	}
+}
+
+func TestWaitForLogAnalyticsWorkspaceLinkedServiceTags(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	expected := map[string]*string{
+		"environment": utils.String("production"),
+	}
+
+	// the first few reads return the tags from before the update
+	calls := 0
+	get := func() (operationalinsights.LinkedService, error) {
+		calls++
+		if calls < 3 {
+			return operationalinsights.LinkedService{
+				Tags: map[string]*string{
+					"environment": utils.String("staging"),
+				},
+			}, nil
+		}
+
+		return operationalinsights.LinkedService{
+			Tags: expected,
+		}, nil
+	}
+
+	read, err := waitForLogAnalyticsWorkspaceLinkedServiceTags(ctx, get, expected)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("Expected 3 reads but got %d", calls)
+	}
+	if !logAnalyticsWorkspaceLinkedServiceTagsMatch(expected, read.Tags) {
+		t.Fatalf("Expected the Tags %+v but got %+v", expected, read.Tags)
+	}
+
+	// when the tags never propagate within the timeout the last read is returned rather than an error
+	stale := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{}, nil
+	}
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), time.Second)
+	defer shortCancel()
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceTags(shortCtx, stale, expected); err != nil {
+		t.Fatalf("Expected no error when the Tags haven't propagated but got: %+v", err)
+	}
+
+	// and once the Context has been cancelled it stops waiting
+	cancelledCtx, cancelled := context.WithCancel(context.Background())
+	cancelled()
+	calls = 0
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceTags(cancelledCtx, get, expected); err != nil {
+		t.Fatalf("Expected no error when the Context has been cancelled but got: %+v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("Expected no reads once the Context has been cancelled but got %d", calls)
+	}
+
+	// tag keys returned with a different casing are treated as matching
+	differentlyCased := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{
+			Tags: map[string]*string{
+				"ENVIRONMENT": utils.String("production"),
+			},
+		}, nil
+	}
+	read, err = waitForLogAnalyticsWorkspaceLinkedServiceTags(ctx, differentlyCased, expected)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if _, ok := normalizeTagKeys(read.Tags, "environment")["environment"]; !ok {
+		t.Fatalf("Expected the Tag key %q to be normalized but got %+v", "environment", read.Tags)
+	}
+
+	// but errors from the API are returned
+	failing := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{}, fmt.Errorf("internal server error")
+	}
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceTags(ctx, failing, expected); err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+}
+
+func TestGetLogAnalyticsWorkspaceLinkedServiceForRead(t *testing.T) {
+	linkedService := operationalinsights.LinkedService{
+		ID:   utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"),
+		Name: utils.String("workspace1/Automation"),
+	}
+	response := func(statusCode int) autorest.Response {
+		return autorest.Response{
+			Response: &http.Response{
+				StatusCode: statusCode,
+			},
+		}
+	}
+
+	found := func() (operationalinsights.LinkedService, error) {
+		return linkedService, nil
+	}
+	notFound := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{Response: response(http.StatusNotFound)}, fmt.Errorf("linked service not found")
+	}
+	badRequest := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{Response: response(http.StatusBadRequest)}, fmt.Errorf("bad request")
+	}
+	emptyBody := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{Response: response(http.StatusOK)}, nil
+	}
+	emptyList := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{Value: &[]operationalinsights.LinkedService{}}, nil
+	}
+	listFound := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{Value: &[]operationalinsights.LinkedService{linkedService}}, nil
+	}
+	listWithoutID := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{
+			Value: &[]operationalinsights.LinkedService{
+				{
+					Name: utils.String("workspace1/Automation"),
+				},
+			},
+		}, nil
+	}
+	listError := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{Response: response(http.StatusInternalServerError)}, fmt.Errorf("internal server error")
+	}
+	listWorkspaceNotFound := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{Response: response(http.StatusNotFound)}, fmt.Errorf("workspace not found")
+	}
+	workspaceFound := func() (operationalinsights.Workspace, error) {
+		return operationalinsights.Workspace{Response: response(http.StatusOK)}, nil
+	}
+	workspaceNotFound := func() (operationalinsights.Workspace, error) {
+		return operationalinsights.Workspace{Response: response(http.StatusNotFound)}, fmt.Errorf("workspace not found")
+	}
+
+	cases := []struct {
+		Name         string
+		Get          func() (operationalinsights.LinkedService, error)
+		List         func() (operationalinsights.LinkedServiceListResult, error)
+		GetWorkspace func() (operationalinsights.Workspace, error)
+		Found        bool
+		ShouldError  bool
+	}{
+		{
+			Name:         "found",
+			Get:          found,
+			List:         emptyList,
+			GetWorkspace: workspaceFound,
+			Found:        true,
+		},
+		{
+			Name:         "linked service deleted",
+			Get:          notFound,
+			List:         emptyList,
+			GetWorkspace: workspaceFound,
+			Found:        false,
+		},
+		{
+			Name:         "workspace deleted",
+			Get:          notFound,
+			List:         listWorkspaceNotFound,
+			GetWorkspace: workspaceNotFound,
+			Found:        false,
+		},
+		{
+			// the Get doesn't always return a 404 when the Workspace has been deleted
+			Name:         "workspace deleted with a non-404 error",
+			Get:          badRequest,
+			List:         listWorkspaceNotFound,
+			GetWorkspace: workspaceNotFound,
+			Found:        false,
+		},
+		{
+			Name:         "error with an existing workspace",
+			Get:          badRequest,
+			List:         emptyList,
+			GetWorkspace: workspaceFound,
+			ShouldError:  true,
+		},
+		{
+			// an empty 200 is malformed rather than meaning the Linked Service has been deleted
+			Name:         "empty body",
+			Get:          emptyBody,
+			List:         emptyList,
+			GetWorkspace: workspaceFound,
+			ShouldError:  true,
+		},
+		{
+			// the List is only consulted for a 404
+			Name:         "empty body for a linked service within the list",
+			Get:          emptyBody,
+			List:         listFound,
+			GetWorkspace: workspaceFound,
+			ShouldError:  true,
+		},
+		{
+			// the Get can transiently 404 shortly after creation
+			Name:         "not found for a linked service within the list",
+			Get:          notFound,
+			List:         listFound,
+			GetWorkspace: workspaceFound,
+			Found:        true,
+		},
+		{
+			Name:         "not found with a malformed list",
+			Get:          notFound,
+			List:         listWithoutID,
+			GetWorkspace: workspaceFound,
+			ShouldError:  true,
+		},
+		{
+			Name:         "not found with a failed list",
+			Get:          notFound,
+			List:         listError,
+			GetWorkspace: workspaceFound,
+			ShouldError:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := getLogAnalyticsWorkspaceLinkedServiceForRead(tc.Get, tc.List, tc.GetWorkspace, "group1", "workspace1", "automation")
+			if tc.ShouldError {
+				if err == nil {
+					t.Fatalf("Expected an error but didn't get one")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			if (actual != nil) != tc.Found {
+				t.Fatalf("Expected the Linked Service to be found %t but got %+v", tc.Found, actual)
+			}
+		})
+	}
+}
+
+func TestGetLogAnalyticsWorkspaceLinkedServiceForReadWithReadOnlyCredentials(t *testing.T) {
+	linkedService := operationalinsights.LinkedService{
+		ID:   utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"),
+		Name: utils.String("workspace1/Automation"),
+	}
+	forbidden := autorest.Response{
+		Response: &http.Response{
+			StatusCode: http.StatusForbidden,
+		},
+	}
+
+	// the credentials can read the Linked Service, so the read succeeds - since only reads are possible here
+	// this also ensures that reading the Linked Service never needs to write it
+	readOnlyGet := func() (operationalinsights.LinkedService, error) {
+		return linkedService, nil
+	}
+	readOnlyList := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{Value: &[]operationalinsights.LinkedService{linkedService}}, nil
+	}
+	getWorkspace := func() (operationalinsights.Workspace, error) {
+		return operationalinsights.Workspace{}, nil
+	}
+
+	read, err := getLogAnalyticsWorkspaceLinkedServiceForRead(readOnlyGet, readOnlyList, getWorkspace, "group1", "workspace1", "automation")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if read == nil {
+		t.Fatalf("Expected the Linked Service to be found but it wasn't")
+	}
+
+	// whereas credentials which can't read the Linked Service should get an error explaining the missing read permission
+	noAccessGet := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{Response: forbidden}, fmt.Errorf("forbidden")
+	}
+	_, err = getLogAnalyticsWorkspaceLinkedServiceForRead(noAccessGet, readOnlyList, getWorkspace, "group1", "workspace1", "automation")
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "permission to read") || !strings.Contains(err.Error(), logAnalyticsWorkspaceLinkedServiceReadAction) {
+		t.Fatalf("Expected the error to explain the missing read permission but got: %+v", err)
+	}
+
+	writeErr := logAnalyticsWorkspaceLinkedServiceForbiddenError(logAnalyticsWorkspaceLinkedServiceWriteAction, "automation", "workspace1", "group1", fmt.Errorf("forbidden"))
+	if !strings.Contains(writeErr.Error(), "permission to write") || !strings.Contains(writeErr.Error(), logAnalyticsWorkspaceLinkedServiceWriteAction) {
+		t.Fatalf("Expected the error to explain the missing write permission but got: %+v", writeErr)
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceResponseIsRetryable(t *testing.T) {
+	cases := []struct {
+		StatusCode int
+		Expected   bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusBadRequest, false},
+		{http.StatusNotFound, false},
+		{http.StatusConflict, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusNotImplemented, false},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+		{http.StatusHTTPVersionNotSupported, false},
+		{http.StatusNetworkAuthenticationRequired, false},
+	}
+
+	for _, tc := range cases {
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: tc.StatusCode,
+			},
+		}
+		if actual := logAnalyticsWorkspaceLinkedServiceResponseIsRetryable(resp); actual != tc.Expected {
+			t.Fatalf("Expected the Status Code %d to be retryable %t but got %t", tc.StatusCode, tc.Expected, actual)
+		}
+	}
+
+	if logAnalyticsWorkspaceLinkedServiceResponseIsRetryable(autorest.Response{}) {
+		t.Fatalf("Expected a request without a response not to be retryable")
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceRetryAfter(t *testing.T) {
+	withRetryAfter := func(value string) autorest.Response {
+		return autorest.Response{
+			Response: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"Retry-After": []string{value},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Response autorest.Response
+		Attempt  int
+		Expected time.Duration
+	}{
+		{
+			Name:     "retry after seconds",
+			Response: withRetryAfter("17"),
+			Attempt:  1,
+			Expected: 17 * time.Second,
+		},
+		{
+			Name:     "retry after a date in the past",
+			Response: withRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT"),
+			Attempt:  1,
+			Expected: 0,
+		},
+		{
+			Name:     "invalid retry after",
+			Response: withRetryAfter("soon"),
+			Attempt:  1,
+			Expected: 5 * time.Second,
+		},
+		{
+			Name:     "no response",
+			Response: autorest.Response{},
+			Attempt:  1,
+			Expected: 5 * time.Second,
+		},
+		{
+			Name:     "backoff",
+			Response: autorest.Response{},
+			Attempt:  3,
+			Expected: 20 * time.Second,
+		},
+		{
+			Name:     "backoff is capped",
+			Response: autorest.Response{},
+			Attempt:  10,
+			Expected: time.Minute,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := logAnalyticsWorkspaceLinkedServiceRetryAfter(tc.Response, tc.Attempt); actual != tc.Expected {
+				t.Fatalf("Expected to wait %s but got %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(t *testing.T) {
+	response := func(statusCode int) autorest.Response {
+		return autorest.Response{
+			Response: &http.Response{
+				StatusCode: statusCode,
+				Header: http.Header{
+					"Retry-After": []string{"0"},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		Name          string
+		StatusCodes   []int
+		ExpectedCalls int
+		ShouldError   bool
+	}{
+		{
+			Name:          "success",
+			StatusCodes:   []int{http.StatusOK},
+			ExpectedCalls: 1,
+			ShouldError:   false,
+		},
+		{
+			Name:          "throttled",
+			StatusCodes:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			ExpectedCalls: 3,
+			ShouldError:   false,
+		},
+		{
+			Name:          "transient server error",
+			StatusCodes:   []int{http.StatusServiceUnavailable, http.StatusOK},
+			ExpectedCalls: 2,
+			ShouldError:   false,
+		},
+		{
+			Name:          "validation error",
+			StatusCodes:   []int{http.StatusBadRequest, http.StatusOK},
+			ExpectedCalls: 1,
+			ShouldError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			calls := 0
+			err := retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(context.Background(), "test", func() (autorest.Response, error) {
+				statusCode := tc.StatusCodes[calls]
+				calls++
+				if statusCode != http.StatusOK {
+					return response(statusCode), fmt.Errorf("status code %d", statusCode)
+				}
+				return response(statusCode), nil
+			})
+
+			if (err != nil) != tc.ShouldError {
+				t.Fatalf("Expected an error %t but got %+v", tc.ShouldError, err)
+			}
+			if calls != tc.ExpectedCalls {
+				t.Fatalf("Expected %d calls but got %d", tc.ExpectedCalls, calls)
+			}
+		})
+	}
+
+	// once the Context has been cancelled (e.g. the timeout has been reached) the request isn't retried
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := retryLogAnalyticsWorkspaceLinkedServiceWhenThrottled(ctx, "test", func() (autorest.Response, error) {
+		calls++
+		resp := response(http.StatusTooManyRequests)
+		resp.Header.Set("Retry-After", "60")
+		return resp, fmt.Errorf("throttled")
+	})
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 call but got %d", calls)
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceServiceError(t *testing.T) {
+	serviceError := &az.ServiceError{
+		Code:    "WorkspaceNotFound",
+		Message: "The Workspace was not found",
+		Target:  utils.String("workspace1"),
+	}
+
+	cases := []struct {
+		Name     string
+		Input    error
+		Expected *az.ServiceError
+	}{
+		{
+			Name:     "other error",
+			Input:    fmt.Errorf("connection refused"),
+			Expected: nil,
+		},
+		{
+			Name: "detailed error without a service error",
+			Input: autorest.DetailedError{
+				Original: fmt.Errorf("connection refused"),
+			},
+			Expected: nil,
+		},
+		{
+			Name: "detailed error",
+			Input: autorest.DetailedError{
+				Original: &az.RequestError{
+					ServiceError: serviceError,
+				},
+			},
+			Expected: serviceError,
+		},
+		{
+			Name: "request error",
+			Input: az.RequestError{
+				ServiceError: serviceError,
+			},
+			Expected: serviceError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := logAnalyticsWorkspaceLinkedServiceServiceError(tc.Input); actual != tc.Expected {
+				t.Fatalf("Expected the Service Error %+v but got %+v", tc.Expected, actual)
+			}
+		})
+	}
+
+	// the Code, Message and Target should all be available to include in the error message
+	message := logAnalyticsWorkspaceLinkedServiceServiceError(autorest.DetailedError{Original: &az.RequestError{ServiceError: serviceError}}).Error()
+	for _, expected := range []string{"WorkspaceNotFound", "The Workspace was not found", "workspace1"} {
+		if !strings.Contains(message, expected) {
+			t.Fatalf("Expected %q to contain %q", message, expected)
+		}
+	}
+}
+
+func TestWaitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(t *testing.T) {
+	linkedService := operationalinsights.LinkedService{
+		ID:   utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"),
+		Name: utils.String("workspace1/Automation"),
+	}
+	notFound := operationalinsights.LinkedService{
+		Response: autorest.Response{
+			Response: &http.Response{
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	// the Get 404's but the List returns the Linked Service straight away
+	getCalls := 0
+	delayedGet := func() (operationalinsights.LinkedService, error) {
+		getCalls++
+		return notFound, fmt.Errorf("linked service not found")
+	}
+	listCalls := 0
+	list := func() (operationalinsights.LinkedServiceListResult, error) {
+		listCalls++
+		return operationalinsights.LinkedServiceListResult{
+			Value: &[]operationalinsights.LinkedService{linkedService},
+		}, nil
+	}
+
+	read, err := waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(context.Background(), delayedGet, list, "automation", time.Minute)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if getCalls != 1 || listCalls != 1 {
+		t.Fatalf("Expected 1 Get and 1 List but got %d and %d", getCalls, listCalls)
+	}
+	if read.ID == nil || *read.ID != *linkedService.ID {
+		t.Fatalf("Expected the Linked Service %q but got %+v", *linkedService.ID, read.ID)
+	}
+
+	// neither the Get or the List return it at first
+	getCalls = 0
+	eventualGet := func() (operationalinsights.LinkedService, error) {
+		getCalls++
+		if getCalls < 3 {
+			return notFound, fmt.Errorf("linked service not found")
+		}
+
+		return linkedService, nil
+	}
+	emptyList := func() (operationalinsights.LinkedServiceListResult, error) {
+		return operationalinsights.LinkedServiceListResult{
+			Value: &[]operationalinsights.LinkedService{},
+		}, nil
+	}
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(context.Background(), eventualGet, emptyList, "automation", time.Minute); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if getCalls != 3 {
+		t.Fatalf("Expected 3 Gets but got %d", getCalls)
+	}
+
+	// errors other than a 404 aren't retried
+	failingGet := func() (operationalinsights.LinkedService, error) {
+		return operationalinsights.LinkedService{}, fmt.Errorf("internal server error")
+	}
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(context.Background(), failingGet, emptyList, "automation", time.Minute); err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+
+	// it's an error if the Linked Service never becomes visible, which includes the last Status Code
+	_, err = waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(context.Background(), delayedGet, emptyList, "automation", time.Second)
+	if err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if !strings.Contains(err.Error(), "Status Code 404") {
+		t.Fatalf("Expected the error to contain the last Status Code but got: %+v", err)
+	}
+
+	// and polling stops once the context has been cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	getCalls = 0
+	start := time.Now()
+	if _, err := waitForLogAnalyticsWorkspaceLinkedServiceToBeVisible(ctx, delayedGet, emptyList, "automation", time.Minute); err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if getCalls != 0 {
+		t.Fatalf("Expected no Gets once the context was cancelled but got %d", getCalls)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected polling to stop once the context was cancelled but it took %s", elapsed)
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{
+			Name:     "identical",
+			Old:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			New:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Suppress: true,
+		},
+		{
+			Name:     "subscription id casing",
+			Old:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			New:      "/subscriptions/00000000-0000-0000-0000-00000000000A/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Suppress: true,
+		},
+		{
+			Name:     "provider namespace casing",
+			Old:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/microsoft.automation/automationAccounts/account1",
+			New:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Suppress: true,
+		},
+		{
+			Name:     "resource name casing",
+			Old:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			New:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/Account1",
+			Suppress: false,
+		},
+		{
+			Name:     "different subscription",
+			Old:      "/subscriptions/00000000-0000-0000-0000-00000000000a/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			New:      "/subscriptions/00000000-0000-0000-0000-00000000000b/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Suppress: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if logAnalyticsWorkspaceLinkedServiceResourceIDDiffSuppress("linked_service_properties.0.resource_id", tc.Old, tc.New, nil) != tc.Suppress {
+				t.Fatalf("Expected the diff suppression to return %t for %q == %q", tc.Suppress, tc.Old, tc.New)
+			}
+		})
+	}
+}
+
+func TestParseLogAnalyticsWorkspaceLinkedServiceID(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+		Valid bool
+	}{
+		{
+			Name:  "empty",
+			Input: "",
+			Valid: false,
+		},
+		{
+			Name:  "workspace",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1",
+			Valid: false,
+		},
+		{
+			Name:  "missing linked service name",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/",
+			Valid: false,
+		},
+		{
+			Name:  "different provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/workspaces/workspace1/linkedServices/Automation",
+			Valid: false,
+		},
+		{
+			Name:  "nested too deeply",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation/child/name",
+			Valid: false,
+		},
+		{
+			Name:  "linked service",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation",
+			Valid: true,
+		},
+		{
+			Name:  "lower-cased keys",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/microsoft.operationalinsights/workspaces/workspace1/linkedservices/Automation",
+			Valid: true,
+		},
+		{
+			Name:  "upper-cased keys",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/Providers/Microsoft.OperationalInsights/Workspaces/workspace1/LinkedServices/Automation",
+			Valid: true,
+		},
+		{
+			Name:  "linked service with a different casing for the same key",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/WORKSPACES/workspace1/linkedServices/Automation",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			id, err := parseLogAnalyticsWorkspaceLinkedServiceID(tc.Input)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid && err == nil {
+				t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Input)
+			}
+
+			if tc.Valid && (id.Path["workspaces"] != "workspace1" || id.Path["linkedServices"] != "Automation") {
+				t.Fatalf("Expected the Workspace `workspace1` and Linked Service `Automation` but got %+v", id.Path)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceCanonicalID(t *testing.T) {
+	expected := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"
+	inputs := []string{
+		expected,
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/microsoft.operationalinsights/workspaces/workspace1/linkedservices/Automation",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/Providers/Microsoft.OperationalInsights/Workspaces/workspace1/LinkedServices/Automation",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/providers/Microsoft.OperationalInsights/WORKSPACES/workspace1/LINKEDSERVICES/Automation",
+	}
+
+	for _, input := range inputs {
+		id, err := parseLogAnalyticsWorkspaceLinkedServiceID(input)
+		if err != nil {
+			t.Fatalf("Expected %q to be valid but got: %+v", input, err)
+		}
+
+		actual := logAnalyticsWorkspaceLinkedServiceID(id.SubscriptionID, id.ResourceGroup, id.Path["workspaces"], id.Path["linkedServices"])
+		if actual != expected {
+			t.Fatalf("Expected the canonical ID for %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceResourceGroupName(t *testing.T) {
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/MyGroup1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"
+	cases := []struct {
+		Name          string
+		ResourceGroup string
+		ResourceID    string
+		Expected      string
+	}{
+		{
+			Name:          "same casing",
+			ResourceGroup: "MyGroup1",
+			ResourceID:    resourceID,
+			Expected:      "MyGroup1",
+		},
+		{
+			Name:          "lower-cased",
+			ResourceGroup: "mygroup1",
+			ResourceID:    resourceID,
+			Expected:      "MyGroup1",
+		},
+		{
+			Name:          "mixed-case",
+			ResourceGroup: "MYgroup1",
+			ResourceID:    resourceID,
+			Expected:      "MyGroup1",
+		},
+		{
+			Name:          "different resource group",
+			ResourceGroup: "group2",
+			ResourceID:    resourceID,
+			Expected:      "group2",
+		},
+		{
+			Name:          "invalid resource id",
+			ResourceGroup: "mygroup1",
+			ResourceID:    "workspace1",
+			Expected:      "mygroup1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := logAnalyticsWorkspaceLinkedServiceResourceGroupName(tc.ResourceGroup, tc.ResourceID)
+			if actual != tc.Expected {
+				t.Fatalf("Expected the Resource Group %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceImportID(t *testing.T) {
+	subscriptionId := "00000000-0000-0000-0000-000000000000"
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected string
+		Valid    bool
+	}{
+		{
+			Name:     "resource id",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation",
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation",
+			Valid:    true,
+		},
+		{
+			Name:     "shorthand",
+			Input:    "group1/workspace1/automation",
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/automation",
+			Valid:    true,
+		},
+		{
+			Name:  "shorthand for a cluster",
+			Input: "group1/workspace1/Cluster",
+			Valid: false,
+		},
+		{
+			Name:  "shorthand missing a segment",
+			Input: "group1/workspace1",
+			Valid: false,
+		},
+		{
+			Name:  "shorthand with too many segments",
+			Input: "group1/workspace1/automation/extra",
+			Valid: false,
+		},
+		{
+			Name:  "shorthand with an invalid workspace name",
+			Input: "group1/-workspace1/automation",
+			Valid: false,
+		},
+		{
+			Name:  "shorthand with an invalid resource group name",
+			Input: "group 1/workspace1/automation",
+			Valid: false,
+		},
+		{
+			Name:  "shorthand with an unsupported linked service",
+			Input: "group1/workspace1/storage",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := logAnalyticsWorkspaceLinkedServiceImportID(tc.Input, subscriptionId)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid && err == nil {
+				t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Input)
+			}
+
+			if actual != tc.Expected {
+				t.Fatalf("Expected the ID %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceActionMatches(t *testing.T) {
+	action := "Microsoft.OperationalInsights/workspaces/linkedServices/write"
+	cases := []struct {
+		Pattern string
+		Matches bool
+	}{
+		{
+			Pattern: "*",
+			Matches: true,
+		},
+		{
+			Pattern: "Microsoft.OperationalInsights/workspaces/linkedServices/write",
+			Matches: true,
+		},
+		{
+			Pattern: "microsoft.operationalinsights/workspaces/linkedservices/write",
+			Matches: true,
+		},
+		{
+			Pattern: "Microsoft.OperationalInsights/*",
+			Matches: true,
+		},
+		{
+			Pattern: "*/write",
+			Matches: true,
+		},
+		{
+			Pattern: "Microsoft.OperationalInsights/*/linkedServices/*",
+			Matches: true,
+		},
+		{
+			Pattern: "*/read",
+			Matches: false,
+		},
+		{
+			Pattern: "Microsoft.OperationalInsights/workspaces/linkedServices",
+			Matches: false,
+		},
+		{
+			Pattern: "Microsoft.Automation/*",
+			Matches: false,
+		},
+		{
+			// the prefix and suffix can't overlap
+			Pattern: "Microsoft.OperationalInsights/workspaces/linkedServices/write*write",
+			Matches: false,
+		},
+		{
+			// characters which have a meaning within a regular expression are matched literally
+			Pattern: "Microsoft.OperationalInsights/workspaces/linkedServices/(write",
+			Matches: false,
+		},
+		{
+			Pattern: "Microsoft.OperationalInsights.workspaces.linkedServices.write",
+			Matches: false,
+		},
+	}
+
+	for _, tc := range cases {
+		if matches := logAnalyticsWorkspaceLinkedServiceActionMatches(tc.Pattern, action); matches != tc.Matches {
+			t.Fatalf("Expected the pattern %q to match %q to be %t but got %t", tc.Pattern, action, tc.Matches, matches)
+		}
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServicePermissionsAllow(t *testing.T) {
+	writeAction := "Microsoft.OperationalInsights/workspaces/linkedServices/write"
+	cases := []struct {
+		Name        string
+		Permissions []authorization.Permission
+		Allowed     bool
+	}{
+		{
+			Name:        "no permissions",
+			Permissions: []authorization.Permission{},
+			Allowed:     false,
+		},
+		{
+			Name: "owner",
+			Permissions: []authorization.Permission{
+				{
+					Actions: &[]string{"*"},
+				},
+			},
+			Allowed: true,
+		},
+		{
+			Name: "reader",
+			Permissions: []authorization.Permission{
+				{
+					Actions: &[]string{"*/read"},
+				},
+			},
+			Allowed: false,
+		},
+		{
+			Name: "provider wildcard",
+			Permissions: []authorization.Permission{
+				{
+					Actions: &[]string{"Microsoft.OperationalInsights/*"},
+				},
+			},
+			Allowed: true,
+		},
+		{
+			Name: "exact action different casing",
+			Permissions: []authorization.Permission{
+				{
+					Actions: &[]string{"microsoft.operationalinsights/workspaces/linkedservices/write"},
+				},
+			},
+			Allowed: true,
+		},
+		{
+			Name: "excluded by not actions",
+			Permissions: []authorization.Permission{
+				{
+					Actions:    &[]string{"*"},
+					NotActions: &[]string{"Microsoft.OperationalInsights/workspaces/linkedServices/*"},
+				},
+			},
+			Allowed: false,
+		},
+		{
+			Name: "allowed by another role",
+			Permissions: []authorization.Permission{
+				{
+					Actions: &[]string{"*/read"},
+				},
+				{
+					Actions: &[]string{"Microsoft.OperationalInsights/workspaces/*"},
+				},
+			},
+			Allowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if allowed := logAnalyticsWorkspaceLinkedServicePermissionsAllow(tc.Permissions, writeAction); allowed != tc.Allowed {
+				t.Fatalf("Expected %q to be allowed %t but got %t", writeAction, tc.Allowed, allowed)
+			}
+		})
+	}
+}
+
+func TestFindLogAnalyticsWorkspaceLinkedServiceDependentSolutions(t *testing.T) {
+	workspaceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1"
+	solutions := []operationsmanagement.Solution{
+		{
+			Name: utils.String("Updates(workspace1)"),
+			Properties: &operationsmanagement.SolutionProperties{
+				WorkspaceResourceID: utils.String(strings.ToLower(workspaceID)),
+			},
+		},
+		{
+			Name: utils.String("ChangeTracking(workspace1)"),
+			Properties: &operationsmanagement.SolutionProperties{
+				WorkspaceResourceID: utils.String(workspaceID),
+			},
+		},
+		{
+			Name: utils.String("ContainerInsights(workspace1)"),
+			Properties: &operationsmanagement.SolutionProperties{
+				WorkspaceResourceID: utils.String(workspaceID),
+			},
+		},
+		{
+			Name: utils.String("Updates(workspace2)"),
+			Properties: &operationsmanagement.SolutionProperties{
+				WorkspaceResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace2"),
+			},
+		},
+		{
+			Name: utils.String("Updates(workspace3)"),
+		},
+	}
+
+	names := findLogAnalyticsWorkspaceLinkedServiceDependentSolutions(&solutions, workspaceID)
+	if len(names) != 2 || names[0] != "Updates(workspace1)" || names[1] != "ChangeTracking(workspace1)" {
+		t.Fatalf("Expected `Updates(workspace1)` and `ChangeTracking(workspace1)` to be dependent Solutions but got %+v", names)
+	}
+
+	if names := findLogAnalyticsWorkspaceLinkedServiceDependentSolutions(nil, workspaceID); len(names) != 0 {
+		t.Fatalf("Expected no dependent Solutions but got %+v", names)
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceWorkspaceSku(t *testing.T) {
+	cases := []struct {
+		Sku   *operationalinsights.Sku
+		Valid bool
+	}{
+		{
+			Sku:   nil,
+			Valid: true,
+		},
+		{
+			Sku:   &operationalinsights.Sku{Name: operationalinsights.Free},
+			Valid: false,
+		},
+		{
+			Sku:   &operationalinsights.Sku{Name: operationalinsights.Standalone},
+			Valid: false,
+		},
+		{
+			Sku:   &operationalinsights.Sku{Name: operationalinsights.PerGB2018},
+			Valid: true,
+		},
+		{
+			Sku:   &operationalinsights.Sku{Name: operationalinsights.PerNode},
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateLogAnalyticsWorkspaceLinkedServiceWorkspaceSku(tc.Sku)
+		if tc.Valid && err != nil {
+			t.Fatalf("Expected the SKU %+v to be valid but got: %+v", tc.Sku, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("Expected the SKU %+v to be invalid but didn't get an error", tc.Sku)
+		}
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceAutomationAccountSku(t *testing.T) {
+	accountWithSku := func(sku *automation.Sku) func() (automation.Account, error) {
+		return func() (automation.Account, error) {
+			return automation.Account{
+				AccountProperties: &automation.AccountProperties{
+					Sku: sku,
+				},
+			}, nil
+		}
+	}
+
+	cases := []struct {
+		Name  string
+		Get   func() (automation.Account, error)
+		Valid bool
+	}{
+		{
+			Name:  "Basic",
+			Get:   accountWithSku(&automation.Sku{Name: automation.Basic}),
+			Valid: true,
+		},
+		{
+			Name:  "Free",
+			Get:   accountWithSku(&automation.Sku{Name: automation.Free}),
+			Valid: false,
+		},
+		{
+			Name:  "no SKU",
+			Get:   accountWithSku(nil),
+			Valid: true,
+		},
+		{
+			Name: "no properties",
+			Get: func() (automation.Account, error) {
+				return automation.Account{}, nil
+			},
+			Valid: true,
+		},
+		{
+			// the check is best-effort
+			Name: "unable to retrieve",
+			Get: func() (automation.Account, error) {
+				return automation.Account{}, fmt.Errorf("authorization failed")
+			},
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateLogAnalyticsWorkspaceLinkedServiceAutomationAccountSku(tc.Get)
+		if tc.Valid && err != nil {
+			t.Fatalf("Expected %q to be valid but got: %+v", tc.Name, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Name)
+		}
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceProperties(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input []interface{}
+		Valid bool
+	}{
+		{
+			Name:  "omitted",
+			Input: []interface{}{},
+			Valid: true,
+		},
+		{
+			Name:  "empty block",
+			Input: []interface{}{nil},
+			Valid: false,
+		},
+		{
+			Name:  "empty map",
+			Input: []interface{}{map[string]interface{}{}},
+			Valid: false,
+		},
+		{
+			Name: "empty resource id",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_id": "",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "resource id",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+				},
+			},
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateLogAnalyticsWorkspaceLinkedServiceProperties(tc.Input)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %+v to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid {
+				if err == nil {
+					t.Fatalf("Expected %+v to be invalid but didn't get an error", tc.Input)
+				}
+				if !strings.Contains(err.Error(), "linked_service_properties.0.resource_id") {
+					t.Fatalf("Expected the error to name `linked_service_properties.0.resource_id` but got: %+v", err)
+				}
+			}
+
+			// expanding the same input mustn't panic either
+			expandLogAnalyticsWorkspaceLinkedServiceResourceID("", tc.Input)
+		})
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceResourceExists(t *testing.T) {
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/deleted"
+	cases := []struct {
+		Name       string
+		StatusCode int
+		Error      error
+		Valid      bool
+	}{
+		{
+			Name:       "exists",
+			StatusCode: http.StatusOK,
+			Valid:      true,
+		},
+		{
+			Name:       "deleted",
+			StatusCode: http.StatusNotFound,
+			Error:      fmt.Errorf("not found"),
+			Valid:      false,
+		},
+		{
+			Name:       "unable to read",
+			StatusCode: http.StatusForbidden,
+			Error:      fmt.Errorf("forbidden"),
+			Valid:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateLogAnalyticsWorkspaceLinkedServiceResourceExists(resourceID, func() (autorest.Response, error) {
+				return autorest.Response{Response: &http.Response{StatusCode: tc.StatusCode}}, tc.Error
+			})
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected no error but got: %+v", err)
+			}
+			if !tc.Valid {
+				expected := fmt.Sprintf("referenced resource %s does not exist", resourceID)
+				if err == nil || err.Error() != expected {
+					t.Fatalf("Expected the error %q but got: %+v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceDiffRequiresNew(t *testing.T) {
+	resourceID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"
+	state := &terraform.InstanceState{
+		ID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation",
+		Attributes: map[string]string{
+			"resource_group_name":                     "group1",
+			"workspace_name":                          "workspace1",
+			"linked_service_name":                     "automation",
+			"resource_id":                             resourceID,
+			"linked_service_properties.#":             "1",
+			"linked_service_properties.0.resource_id": resourceID,
+			"purge_on_destroy":                        "false",
+			"force_destroy":                           "false",
+			"tags.%":                                  "1",
+			"tags.environment":                        "test",
+		},
+	}
+
+	cases := []struct {
+		Name        string
+		Config      map[string]interface{}
+		RequiresNew bool
+	}{
+		{
+			Name: "tags changed",
+			Config: map[string]interface{}{
+				"resource_group_name": "group1",
+				"workspace_name":      "workspace1",
+				"resource_id":         resourceID,
+				"tags": map[string]interface{}{
+					"environment": "production",
+				},
+			},
+			RequiresNew: false,
+		},
+		{
+			Name: "tags changed using linked_service_properties",
+			Config: map[string]interface{}{
+				"resource_group_name": "group1",
+				"workspace_name":      "workspace1",
+				"linked_service_properties": []interface{}{
+					map[string]interface{}{
+						"resource_id": resourceID,
+					},
+				},
+				"tags": map[string]interface{}{
+					"environment": "production",
+					"owner":       "ops",
+				},
+			},
+			RequiresNew: false,
+		},
+		{
+			Name: "resource id changed",
+			Config: map[string]interface{}{
+				"resource_group_name": "group1",
+				"workspace_name":      "workspace1",
+				"resource_id":         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account2",
+				"tags": map[string]interface{}{
+					"environment": "test",
+				},
+			},
+			RequiresNew: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			raw, err := config.NewRawConfig(tc.Config)
+			if err != nil {
+				t.Fatalf("Error building the config: %+v", err)
+			}
+
+			diff, err := resourceArmLogAnalyticsWorkspaceLinkedService().Diff(state, terraform.NewResourceConfig(raw), nil)
+			if err != nil {
+				t.Fatalf("Error diffing: %+v", err)
+			}
+
+			if diff == nil || diff.Empty() {
+				t.Fatalf("Expected a diff but didn't get one")
+			}
+
+			if diff.RequiresNew() != tc.RequiresNew {
+				t.Fatalf("Expected RequiresNew to be %t but got %t: %+v", tc.RequiresNew, diff.RequiresNew(), diff)
+			}
+		})
+	}
+}
+
+func TestMergeLogAnalyticsWorkspaceLinkedServiceProperties(t *testing.T) {
+	existingID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"
+	configuredID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account2"
+
+	cases := []struct {
+		Name              string
+		Existing          *operationalinsights.LinkedServiceProperties
+		ResourceID        string
+		ResourceIDChanged bool
+		Expected          string
+	}{
+		{
+			Name:       "tags only with no resource id in the config",
+			Existing:   &operationalinsights.LinkedServiceProperties{ResourceID: utils.String(existingID)},
+			ResourceID: "",
+			Expected:   existingID,
+		},
+		{
+			Name:       "tags only with a resource id in the config",
+			Existing:   &operationalinsights.LinkedServiceProperties{ResourceID: utils.String(existingID)},
+			ResourceID: configuredID,
+			Expected:   existingID,
+		},
+		{
+			Name:              "resource id changed",
+			Existing:          &operationalinsights.LinkedServiceProperties{ResourceID: utils.String(existingID)},
+			ResourceID:        configuredID,
+			ResourceIDChanged: true,
+			Expected:          configuredID,
+		},
+		{
+			Name:       "no existing properties",
+			Existing:   nil,
+			ResourceID: configuredID,
+			Expected:   configuredID,
+		},
+		{
+			Name:       "no existing resource id",
+			Existing:   &operationalinsights.LinkedServiceProperties{},
+			ResourceID: configuredID,
+			Expected:   configuredID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := mergeLogAnalyticsWorkspaceLinkedServiceProperties(tc.Existing, tc.ResourceID, tc.ResourceIDChanged)
+			if actual == nil || actual.ResourceID == nil {
+				t.Fatalf("Expected the Resource ID %q but got nil", tc.Expected)
+			}
+
+			if *actual.ResourceID != tc.Expected {
+				t.Fatalf("Expected the Resource ID %q but got %q", tc.Expected, *actual.ResourceID)
+			}
+		})
+	}
+
+	// the existing properties shouldn't be modified
+	existing := &operationalinsights.LinkedServiceProperties{ResourceID: utils.String(existingID)}
+	mergeLogAnalyticsWorkspaceLinkedServiceProperties(existing, configuredID, true)
+	if *existing.ResourceID != existingID {
+		t.Fatalf("Expected the existing Resource ID to be unchanged but got %q", *existing.ResourceID)
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServicePreserveCasing(t *testing.T) {
+	cases := []struct {
+		Existing string
+		FromID   string
+		Expected string
+	}{
+		{
+			Existing: "acctestLAW-1234",
+			FromID:   "acctestlaw-1234",
+			Expected: "acctestLAW-1234",
+		},
+		{
+			Existing: "acctestlaw-1234",
+			FromID:   "acctestlaw-1234",
+			Expected: "acctestlaw-1234",
+		},
+		{
+			// when importing
+			Existing: "",
+			FromID:   "acctestlaw-1234",
+			Expected: "acctestlaw-1234",
+		},
+		{
+			Existing: "acctestLAW-1234",
+			FromID:   "acctestlaw-5678",
+			Expected: "acctestlaw-5678",
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := logAnalyticsWorkspaceLinkedServicePreserveCasing(tc.Existing, tc.FromID); actual != tc.Expected {
+			t.Fatalf("Expected %q but got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestValidateLogAnalyticsWorkspaceLinkedServiceReplacement(t *testing.T) {
+	accountID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"
+	otherAccountID := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account2"
+	linkedServiceTo := func(resourceID string) operationalinsights.LinkedService {
+		return operationalinsights.LinkedService{
+			ID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/workspaces/workspace1/linkedServices/Automation"),
+			LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String(resourceID),
+			},
+		}
+	}
+
+	cases := []struct {
+		Name             string
+		Existing         operationalinsights.LinkedService
+		AllowReplacement bool
+		ShouldError      bool
+	}{
+		{
+			Name:             "no existing linked service",
+			Existing:         operationalinsights.LinkedService{},
+			AllowReplacement: false,
+			ShouldError:      false,
+		},
+		{
+			Name:             "existing linked service to the same account",
+			Existing:         linkedServiceTo(strings.ToLower(accountID)),
+			AllowReplacement: false,
+			ShouldError:      false,
+		},
+		{
+			Name:             "existing linked service to a different account",
+			Existing:         linkedServiceTo(otherAccountID),
+			AllowReplacement: false,
+			ShouldError:      true,
+		},
+		{
+			Name:             "existing linked service to a different account with replacement allowed",
+			Existing:         linkedServiceTo(otherAccountID),
+			AllowReplacement: true,
+			ShouldError:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateLogAnalyticsWorkspaceLinkedServiceReplacement(tc.Existing, accountID, tc.AllowReplacement)
+			if (err != nil) != tc.ShouldError {
+				t.Fatalf("Expected an error %t but got %+v", tc.ShouldError, err)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceKind(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    *operationalinsights.LinkedServiceProperties
+		Expected string
+	}{
+		{
+			Name:     "nil",
+			Input:    nil,
+			Expected: "",
+		},
+		{
+			Name:     "empty",
+			Input:    &operationalinsights.LinkedServiceProperties{},
+			Expected: "",
+		},
+		{
+			Name: "read access resource id",
+			Input: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"),
+			},
+			Expected: "automation",
+		},
+		{
+			Name: "cluster resource id",
+			Input: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1"),
+			},
+			Expected: "",
+		},
+		{
+			Name: "unsupported resource id",
+			Input: &operationalinsights.LinkedServiceProperties{
+				ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"),
+			},
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := logAnalyticsWorkspaceLinkedServiceKind(tc.Input); actual != tc.Expected {
+				t.Fatalf("Expected the kind %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceResourceType(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			Name:     "empty",
+			Input:    "",
+			Expected: "",
+		},
+		{
+			Name:     "invalid resource id",
+			Input:    "not-a-resource-id",
+			Expected: "",
+		},
+		{
+			Name:     "resource group",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			Expected: "",
+		},
+		{
+			Name:     "automation account",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Expected: "Microsoft.Automation/automationAccounts",
+		},
+		{
+			Name:     "cluster",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1",
+			Expected: "Microsoft.OperationalInsights/clusters",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := logAnalyticsWorkspaceLinkedServiceResourceType(tc.Input); actual != tc.Expected {
+				t.Fatalf("Expected the resource type %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceAutomationAccountName(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			Name:     "empty",
+			Input:    "",
+			Expected: "",
+		},
+		{
+			Name:     "automation account",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1",
+			Expected: "account1",
+		},
+		{
+			Name:     "automation account with a different casing",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/microsoft.automation/AutomationAccounts/Account1",
+			Expected: "Account1",
+		},
+		{
+			Name:     "cluster",
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.OperationalInsights/clusters/cluster1",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := logAnalyticsWorkspaceLinkedServiceAutomationAccountName(tc.Input); actual != tc.Expected {
+				t.Fatalf("Expected the Automation Account name %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestLogAnalyticsWorkspaceLinkedServiceRedactedPayload(t *testing.T) {
+	input := operationalinsights.LinkedService{
+		Tags: map[string]*string{
+			"secret": utils.String("hunter2"),
+		},
+		LinkedServiceProperties: &operationalinsights.LinkedServiceProperties{
+			ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"),
+		},
+	}
+
+	payload := logAnalyticsWorkspaceLinkedServiceRedactedPayload(input)
+	if strings.Contains(payload, "hunter2") {
+		t.Fatalf("Expected the tag values to be redacted but got: %s", payload)
+	}
+	if !strings.Contains(payload, `"secret":"REDACTED"`) {
+		t.Fatalf("Expected the tag keys to be retained but got: %s", payload)
+	}
+	if !strings.Contains(payload, `"resourceId":"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Automation/automationAccounts/account1"`) {
+		t.Fatalf("Expected the payload to contain the Resource ID but got: %s", payload)
+	}
+
+	// the original tags shouldn't be modified
+	if *input.Tags["secret"] != "hunter2" {
+		t.Fatalf("Expected the input tags to be unchanged but got %q", *input.Tags["secret"])
+	}
+}
+
 func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(t *testing.T) {
 	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
 	ri := tf.AccRandTimeInt()
@@ -20,32 +2034,125 @@ func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(t *testing.T) {
 		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, testLocation()),
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctestlaw-%d/Automation", ri)),
+					resource.TestCheckResourceAttr(resourceName, "workspace_name", fmt.Sprintf("acctestLAW-%d", ri)),
+					resource.TestCheckResourceAttr(resourceName, "linked_service_name", "automation"),
+					resource.TestCheckResourceAttr(resourceName, "kind", "automation"),
+					resource.TestCheckResourceAttr(resourceName, "linked_resource_type", "Microsoft.Automation/automationAccounts"),
+					resource.TestCheckResourceAttrPair(resourceName, "automation_account_name", "azurerm_automation_account.test", "name"),
+					resource.TestCheckResourceAttrPair(resourceName, "workspace_customer_id", "azurerm_log_analytics_workspace.test", "workspace_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "workspace_id", "azurerm_log_analytics_workspace.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_id", "azurerm_automation_account.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "linked_service_properties.#", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purge_on_destroy", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_importNonExistent(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Not found: %s", resourceName)
+					}
+
+					return strings.TrimSuffix(rs.Primary.ID, "Automation") + "doesnotexist", nil
+				},
+				ExpectError: regexp.MustCompile("was not found"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(t *testing.T) {
+	if !requireResourcesToBeImported {
+		t.Skip("Skipping since resources aren't required to be imported")
+		return
+	}
+
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctestlaw-%d/Automation", ri)),
+					resource.TestCheckResourceAttr(resourceName, "workspace_name", fmt.Sprintf("acctestLAW-%d", ri)),
+					resource.TestCheckResourceAttr(resourceName, "linked_service_name", "automation"),
+				),
+			},
+			{
+				Config:      testAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(ri, location),
+				ExpectError: testRequiresImportError("azurerm_log_analytics_workspace_linked_service"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_mixedCaseWorkspaceName(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	config := testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
-					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctestlaw-%d/Automation", ri)),
-					resource.TestCheckResourceAttr(resourceName, "workspace_name", fmt.Sprintf("acctestlaw-%d", ri)),
-					resource.TestCheckResourceAttr(resourceName, "linked_service_name", "automation"),
+					resource.TestCheckResourceAttr(resourceName, "workspace_name", fmt.Sprintf("acctestLAW-%d", ri)),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				// the casing returned from the API differs from the config, which shouldn't cause a diff
+				Config:   config,
+				PlanOnly: true,
 			},
 		},
 	})
 }
 
-func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(t *testing.T) {
-	if !requireResourcesToBeImported {
-		t.Skip("Skipping since resources aren't required to be imported")
-		return
-	}
-
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_mixedCaseResourceGroupName(t *testing.T) {
 	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
 	ri := tf.AccRandTimeInt()
-	location := testLocation()
+	config := testAccAzureRMLogAnalyticsWorkspaceLinkedService_mixedCaseResourceGroupName(ri, testLocation())
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -53,17 +2160,16 @@ func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(t *testing.
 		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, location),
+				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
-					resource.TestCheckResourceAttr(resourceName, "name", fmt.Sprintf("acctestlaw-%d/Automation", ri)),
-					resource.TestCheckResourceAttr(resourceName, "workspace_name", fmt.Sprintf("acctestlaw-%d", ri)),
-					resource.TestCheckResourceAttr(resourceName, "linked_service_name", "automation"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_group_name", "azurerm_resource_group.test", "name"),
 				),
 			},
 			{
-				Config:      testAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(ri, location),
-				ExpectError: testRequiresImportError("azurerm_log_analytics_workspace_linked_service"),
+				// the Resource Group name in the config is upper-cased, which shouldn't cause a diff
+				Config:   config,
+				PlanOnly: true,
 			},
 		},
 	})
@@ -86,9 +2192,177 @@ func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_complete(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purge_on_destroy", "force_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_nestedAttributeOutput(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_nestedAttributeOutput(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "linked_service_properties.#", "1"),
+					func(s *terraform.State) error {
+						rs, ok := s.RootModule().Resources[resourceName]
+						if !ok {
+							return fmt.Errorf("Not found: %s", resourceName)
+						}
+
+						output, ok := s.RootModule().Outputs["resource_id"]
+						if !ok {
+							return fmt.Errorf("Output `resource_id` was not found")
+						}
+
+						expected := rs.Primary.Attributes["linked_service_properties.0.resource_id"]
+						if expected == "" || output.Value != expected {
+							return fmt.Errorf("Expected the Output `resource_id` to be %q but got %q", expected, output.Value)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_ignoreChangesTags(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_ignoreChangesTags(ri, location, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					testAddAzureRMLogAnalyticsWorkspaceLinkedServiceTag(resourceName, "outofband", "true"),
+				),
+			},
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_ignoreChangesTags(ri, location, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "purge_on_destroy", "true"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "test"),
+					resource.TestCheckResourceAttr(resourceName, "tags.outofband", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_updateTags(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+	var id string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_tags(ri, location, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "test"),
+					func(s *terraform.State) error {
+						id = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_tags(ri, location, "production"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "production"),
+					func(s *terraform.State) error {
+						if actual := s.RootModule().Resources[resourceName].Primary.ID; actual != id {
+							return fmt.Errorf("Expected the Linked Service to be updated in-place (with the ID %q) but got the ID %q", id, actual)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolution(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolution(ri, location, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+				),
+			},
+			{
+				// removing the Linked Service whilst the Solution exists should fail
+				Config:      testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolutionWithoutLinkedService(ri, location),
+				ExpectError: regexp.MustCompile("please remove these Solutions first"),
+			},
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolution(ri, location, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLogAnalyticsWorkspaceLinkedService_destroyWithWorkspace(t *testing.T) {
+	resourceName := "azurerm_log_analytics_workspace_linked_service.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName),
+				),
+			},
+			{
+				// removing the Workspace and the Linked Service in the same apply should succeed, since the Linked Service
+				// references the Workspace (via `workspace_name`) and so is destroyed before the Workspace
+				Config: testAccAzureRMLogAnalyticsWorkspaceLinkedService_withoutWorkspace(ri, location),
 			},
 		},
 	})
@@ -156,6 +2430,39 @@ func testCheckAzureRMLogAnalyticsWorkspaceLinkedServiceExists(resourceName strin
 	}
 }
 
+// testAddAzureRMLogAnalyticsWorkspaceLinkedServiceTag adds a tag to the Linked Service outside of Terraform
+func testAddAzureRMLogAnalyticsWorkspaceLinkedServiceTag(resourceName, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		workspaceName := rs.Primary.Attributes["workspace_name"]
+		lsName := rs.Primary.Attributes["linked_service_name"]
+
+		conn := testAccProvider.Meta().(*ArmClient).linkedServicesClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		existing, err := conn.Get(ctx, resourceGroup, workspaceName, lsName)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on Log Analytics Linked Service Client: %+v", err)
+		}
+
+		if existing.Tags == nil {
+			existing.Tags = make(map[string]*string)
+		}
+		existing.Tags[key] = utils.String(value)
+
+		if _, err := conn.CreateOrUpdate(ctx, resourceGroup, workspaceName, lsName, existing); err != nil {
+			return fmt.Errorf("Bad: CreateOrUpdate on Log Analytics Linked Service Client: %+v", err)
+		}
+
+		return nil
+	}
+}
+
 func testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt int, location string) string {
 	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
 	return fmt.Sprintf(`
@@ -164,10 +2471,7 @@ func testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt int, location s
 resource "azurerm_log_analytics_workspace_linked_service" "test" {
   resource_group_name = "${azurerm_resource_group.test.name}"
   workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
-
-  linked_service_properties {
-    resource_id = "${azurerm_automation_account.test.id}"
-  }
+  resource_id         = "${azurerm_automation_account.test.id}"
 }
 `, template)
 }
@@ -180,10 +2484,20 @@ func testAccAzureRMLogAnalyticsWorkspaceLinkedService_requiresImport(rInt int, l
 resource "azurerm_log_analytics_workspace_linked_service" "import" {
   resource_group_name = "${azurerm_log_analytics_workspace_linked_service.test.resource_group_name}"
   workspace_name      = "${azurerm_log_analytics_workspace_linked_service.test.workspace_name}"
+  resource_id         = "${azurerm_automation_account.test.id}"
+}
+`, template)
+}
 
-  linked_service_properties {
-    resource_id = "${azurerm_automation_account.test.id}"
-  }
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_mixedCaseResourceGroupName(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${upper(azurerm_resource_group.test.name)}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  resource_id         = "${azurerm_automation_account.test.id}"
 }
 `, template)
 }
@@ -197,14 +2511,124 @@ resource "azurerm_log_analytics_workspace_linked_service" "test" {
   resource_group_name = "${azurerm_resource_group.test.name}"
   workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
   linked_service_name = "automation"
+  resource_id         = "${azurerm_automation_account.test.id}"
+}
+`, template)
+}
+
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_nestedAttributeOutput(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_basic(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+output "resource_id" {
+  value = "${azurerm_log_analytics_workspace_linked_service.test.linked_service_properties.0.resource_id}"
+}
+`, template)
+}
+
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_ignoreChangesTags(rInt int, location string, purgeOnDestroy bool) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  purge_on_destroy    = %t
+
+  linked_service_properties {
+    resource_id = "${azurerm_automation_account.test.id}"
+  }
+
+  tags {
+    environment = "test"
+  }
+
+  lifecycle {
+    ignore_changes = ["tags"]
+  }
+}
+`, template, purgeOnDestroy)
+}
+
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_tags(rInt int, location string, environment string) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  resource_id         = "${azurerm_automation_account.test.id}"
+
+  tags {
+    environment = "%s"
+  }
+}
+`, template, environment)
+}
+
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolution(rInt int, location string, forceDestroy bool) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolutionWithoutLinkedService(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_linked_service" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  workspace_name      = "${azurerm_log_analytics_workspace.test.name}"
+  force_destroy       = %t
 
   linked_service_properties {
     resource_id = "${azurerm_automation_account.test.id}"
   }
 }
+`, template, forceDestroy)
+}
+
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_dependentSolutionWithoutLinkedService(rInt int, location string) string {
+	template := testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_solution" "test" {
+  solution_name         = "Updates"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  workspace_resource_id = "${azurerm_log_analytics_workspace.test.id}"
+  workspace_name        = "${azurerm_log_analytics_workspace.test.name}"
+
+  plan {
+    publisher = "Microsoft"
+    product   = "OMSGallery/Updates"
+  }
+}
 `, template)
 }
 
+func testAccAzureRMLogAnalyticsWorkspaceLinkedService_withoutWorkspace(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_automation_account" "test" {
+  name                = "acctestAutomation-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    name = "Basic"
+  }
+
+  tags {
+    Environment = "Test"
+  }
+}
+`, rInt, location, rInt)
+}
+
 func testAccAzureRMLogAnalyticsWorkspaceLinkedService_template(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
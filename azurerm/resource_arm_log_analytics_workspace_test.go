@@ -41,6 +41,18 @@ func TestAccAzureRmLogAnalyticsWorkspaceName_validation(t *testing.T) {
 			Value:    str + "a",
 			ErrCount: 1,
 		},
+		{
+			Value:    "ab_c",
+			ErrCount: 1,
+		},
+		{
+			Value:    "ab.c",
+			ErrCount: 1,
+		},
+		{
+			Value:    "a--c",
+			ErrCount: 0,
+		},
 	}
 
 	for _, tc := range cases {
@@ -52,6 +64,13 @@ func TestAccAzureRmLogAnalyticsWorkspaceName_validation(t *testing.T) {
 	}
 }
 
+func BenchmarkValidateAzureRmLogAnalyticsWorkspaceName(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validateAzureRmLogAnalyticsWorkspaceName("acctestLAW-1234567890", "workspace_name")
+	}
+}
+
 func TestAccAzureRMLogAnalyticsWorkspace_basic(t *testing.T) {
 	resourceName := "azurerm_log_analytics_workspace.test"
 	ri := tf.AccRandTimeInt()
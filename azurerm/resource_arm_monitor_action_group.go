@@ -2,10 +2,12 @@ package azurerm
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
@@ -19,7 +21,7 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 		Update: resourceArmMonitorActionGroupCreateUpdate,
 		Delete: resourceArmMonitorActionGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmMonitorActionGroupImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -35,7 +37,7 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 			"short_name": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringLenBetween(1, 12),
+				ValidateFunc: validateMonitorActionGroupShortName,
 			},
 
 			"enabled": {
@@ -45,7 +47,7 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 			},
 
 			"email_receiver": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -64,7 +66,7 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 			},
 
 			"sms_receiver": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -88,7 +90,7 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 			},
 
 			"webhook_receiver": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -106,6 +108,35 @@ func resourceArmMonitorActionGroup() *schema.Resource {
 				},
 			},
 
+			"azure_function_receiver": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"function_app_resource_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+						"function_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"http_trigger_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.URLIsHTTPS,
+						},
+					},
+				},
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -134,9 +165,10 @@ func resourceArmMonitorActionGroupCreateUpdate(d *schema.ResourceData, meta inte
 	shortName := d.Get("short_name").(string)
 	enabled := d.Get("enabled").(bool)
 
-	emailReceiversRaw := d.Get("email_receiver").([]interface{})
-	smsReceiversRaw := d.Get("sms_receiver").([]interface{})
-	webhookReceiversRaw := d.Get("webhook_receiver").([]interface{})
+	emailReceiversRaw := d.Get("email_receiver").(*schema.Set).List()
+	smsReceiversRaw := d.Get("sms_receiver").(*schema.Set).List()
+	webhookReceiversRaw := d.Get("webhook_receiver").(*schema.Set).List()
+	azureFunctionReceiversRaw := d.Get("azure_function_receiver").(*schema.Set).List()
 
 	tags := d.Get("tags").(map[string]interface{})
 	expandedTags := expandTags(tags)
@@ -144,17 +176,31 @@ func resourceArmMonitorActionGroupCreateUpdate(d *schema.ResourceData, meta inte
 	parameters := insights.ActionGroupResource{
 		Location: utils.String(azureRMNormalizeLocation("Global")),
 		ActionGroup: &insights.ActionGroup{
-			GroupShortName:   utils.String(shortName),
-			Enabled:          utils.Bool(enabled),
-			EmailReceivers:   expandMonitorActionGroupEmailReceiver(emailReceiversRaw),
-			SmsReceivers:     expandMonitorActionGroupSmsReceiver(smsReceiversRaw),
-			WebhookReceivers: expandMonitorActionGroupWebHookReceiver(webhookReceiversRaw),
+			GroupShortName:         utils.String(shortName),
+			Enabled:                utils.Bool(enabled),
+			EmailReceivers:         expandMonitorActionGroupEmailReceiver(emailReceiversRaw),
+			SmsReceivers:           expandMonitorActionGroupSmsReceiver(smsReceiversRaw),
+			WebhookReceivers:       expandMonitorActionGroupWebHookReceiver(webhookReceiversRaw),
+			AzureFunctionReceivers: expandMonitorActionGroupAzureFunctionReceiver(azureFunctionReceiversRaw),
 		},
 		Tags: expandedTags,
 	}
 
-	if _, err := client.CreateOrUpdate(ctx, resGroup, name, parameters); err != nil {
-		return fmt.Errorf("Error creating or updating action group %q (resource group %q): %+v", name, resGroup, err)
+	if !d.IsNewResource() && !monitorActionGroupReceiversHaveChanged(d) {
+		// only `enabled` and/or `tags` have changed, so patch these rather than sending the receivers again
+		patch := insights.ActionGroupPatchBody{
+			Tags: expandedTags,
+			ActionGroupPatch: &insights.ActionGroupPatch{
+				Enabled: utils.Bool(enabled),
+			},
+		}
+		if _, err := client.Update(ctx, resGroup, name, patch); err != nil {
+			return fmt.Errorf("Error updating action group %q (resource group %q): %+v", name, resGroup, err)
+		}
+	} else {
+		if _, err := client.CreateOrUpdate(ctx, resGroup, name, parameters); err != nil {
+			return fmt.Errorf("Error creating or updating action group %q (resource group %q): %+v", name, resGroup, err)
+		}
 	}
 
 	read, err := client.Get(ctx, resGroup, name)
@@ -170,11 +216,30 @@ func resourceArmMonitorActionGroupCreateUpdate(d *schema.ResourceData, meta inte
 	return resourceArmMonitorActionGroupRead(d, meta)
 }
 
+// monitorActionGroupReceiversHaveChanged returns whether any of the fields which can't be patched have changed
+func monitorActionGroupReceiversHaveChanged(d *schema.ResourceData) bool {
+	fields := []string{
+		"short_name",
+		"email_receiver",
+		"sms_receiver",
+		"webhook_receiver",
+		"azure_function_receiver",
+	}
+
+	for _, field := range fields {
+		if d.HasChange(field) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func resourceArmMonitorActionGroupRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).monitorActionGroupsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMonitorActionGroupID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -208,6 +273,10 @@ func resourceArmMonitorActionGroupRead(d *schema.ResourceData, meta interface{})
 		if err = d.Set("webhook_receiver", flattenMonitorActionGroupWebHookReceiver(group.WebhookReceivers)); err != nil {
 			return fmt.Errorf("Error setting `webhook_receiver`: %+v", err)
 		}
+
+		if err = d.Set("azure_function_receiver", flattenMonitorActionGroupAzureFunctionReceiver(group.AzureFunctionReceivers)); err != nil {
+			return fmt.Errorf("Error setting `azure_function_receiver`: %+v", err)
+		}
 	}
 
 	flattenAndSetTags(d, resp.Tags)
@@ -219,7 +288,7 @@ func resourceArmMonitorActionGroupDelete(d *schema.ResourceData, meta interface{
 	client := meta.(*ArmClient).monitorActionGroupsClient
 	ctx := meta.(*ArmClient).StopContext
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseMonitorActionGroupID(d.Id())
 	if err != nil {
 		return err
 	}
@@ -236,6 +305,73 @@ func resourceArmMonitorActionGroupDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func resourceArmMonitorActionGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseMonitorActionGroupID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+var monitorActionGroupShortNameRegExp = regexp.MustCompile(`^[a-zA-Z0-9]*$`)
+
+// validateMonitorActionGroupShortName ensures the Short Name (which is used in SMS messages) is between 1 and 12 alphanumeric characters
+func validateMonitorActionGroupShortName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if length := len(value); length < 1 || length > 12 {
+		errors = append(errors, fmt.Errorf("%q must be between 1 and 12 characters but got %d characters (%q)", k, length, value))
+	}
+
+	if !monitorActionGroupShortNameRegExp.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q can only contain letters and numbers but got %q", k, value))
+	}
+
+	return warnings, errors
+}
+
+// parseMonitorActionGroupID parses an Action Group ID, ensuring it's in the format
+// `/subscriptions/{subscriptionId}/resourceGroups/{resourceGroup}/providers/microsoft.insights/actionGroups/{name}` - since
+// IDs are often copied with a different casing the keys are matched case-insensitively
+func parseMonitorActionGroupID(input string) (*ResourceID, error) {
+	format := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroup}/providers/microsoft.insights/actionGroups/{name}"
+
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Action Group ID %q - expected it to be in the format %q: %+v", input, format, err)
+	}
+
+	provider := id.Provider
+	name := ""
+	for k, v := range id.Path {
+		switch {
+		case strings.EqualFold(k, "providers"):
+			provider = v
+		case strings.EqualFold(k, "actionGroups"):
+			name = v
+		default:
+			return nil, fmt.Errorf("Expected the Action Group ID %q to be in the format %q but it contains the unexpected segment %q", input, format, k)
+		}
+	}
+
+	if !strings.EqualFold(provider, "microsoft.insights") {
+		return nil, fmt.Errorf("Expected the Action Group ID %q to be for the provider `microsoft.insights` but got %q - it should be in the format %q", input, provider, format)
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("Expected the Action Group ID %q to be in the format %q but the `actionGroups` segment is missing", input, format)
+	}
+
+	return &ResourceID{
+		SubscriptionID: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+		Provider:       provider,
+		Path: map[string]string{
+			"actionGroups": name,
+		},
+	}, nil
+}
+
 func expandMonitorActionGroupEmailReceiver(v []interface{}) *[]insights.EmailReceiver {
 	receivers := make([]insights.EmailReceiver, 0)
 	for _, receiverValue := range v {
@@ -276,6 +412,21 @@ func expandMonitorActionGroupWebHookReceiver(v []interface{}) *[]insights.Webhoo
 	return &receivers
 }
 
+func expandMonitorActionGroupAzureFunctionReceiver(v []interface{}) *[]insights.AzureFunctionReceiver {
+	receivers := make([]insights.AzureFunctionReceiver, 0)
+	for _, receiverValue := range v {
+		val := receiverValue.(map[string]interface{})
+		receiver := insights.AzureFunctionReceiver{
+			Name:                  utils.String(val["name"].(string)),
+			FunctionAppResourceID: utils.String(val["function_app_resource_id"].(string)),
+			FunctionName:          utils.String(val["function_name"].(string)),
+			HTTPTriggerURL:        utils.String(val["http_trigger_url"].(string)),
+		}
+		receivers = append(receivers, receiver)
+	}
+	return &receivers
+}
+
 func flattenMonitorActionGroupEmailReceiver(receivers *[]insights.EmailReceiver) []interface{} {
 	result := make([]interface{}, 0)
 	if receivers != nil {
@@ -329,3 +480,26 @@ func flattenMonitorActionGroupWebHookReceiver(receivers *[]insights.WebhookRecei
 	}
 	return result
 }
+
+func flattenMonitorActionGroupAzureFunctionReceiver(receivers *[]insights.AzureFunctionReceiver) []interface{} {
+	result := make([]interface{}, 0)
+	if receivers != nil {
+		for _, receiver := range *receivers {
+			val := make(map[string]interface{})
+			if receiver.Name != nil {
+				val["name"] = *receiver.Name
+			}
+			if receiver.FunctionAppResourceID != nil {
+				val["function_app_resource_id"] = *receiver.FunctionAppResourceID
+			}
+			if receiver.FunctionName != nil {
+				val["function_name"] = *receiver.FunctionName
+			}
+			if receiver.HTTPTriggerURL != nil {
+				val["http_trigger_url"] = *receiver.HTTPTriggerURL
+			}
+			result = append(result, val)
+		}
+	}
+	return result
+}
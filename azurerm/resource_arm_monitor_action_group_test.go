@@ -3,8 +3,12 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
@@ -39,6 +43,140 @@ func TestAccAzureRMMonitorActionGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMMonitorActionGroup_importMalformedID(t *testing.T) {
+	resourceName := "azurerm_monitor_action_group.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorActionGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorActionGroup_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorActionGroupExists(resourceName),
+				),
+			},
+			{
+				// the `microsoft.insights` segment is missing
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("/subscriptions/%s/resourceGroups/acctestRG-%d/actionGroups/acctestActionGroup-%d", os.Getenv("ARM_SUBSCRIPTION_ID"), ri, ri),
+				ExpectError:   regexp.MustCompile("to be for the provider `microsoft.insights`"),
+			},
+		},
+	})
+}
+
+func TestParseMonitorActionGroupID(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Input string
+		Valid bool
+	}{
+		{
+			Name:  "empty",
+			Input: "",
+			Valid: false,
+		},
+		{
+			Name:  "missing provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/actionGroups/group1",
+			Valid: false,
+		},
+		{
+			Name:  "different provider",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/actionGroups/group1",
+			Valid: false,
+		},
+		{
+			Name:  "missing action group",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/microsoft.insights/metricAlerts/alert1",
+			Valid: false,
+		},
+		{
+			Name:  "action group",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/microsoft.insights/actionGroups/group1",
+			Valid: true,
+		},
+		{
+			Name:  "action group with a different casing",
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group1/Providers/Microsoft.Insights/ActionGroups/group1",
+			Valid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			id, err := parseMonitorActionGroupID(tc.Input)
+			if tc.Valid && err != nil {
+				t.Fatalf("Expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			if !tc.Valid && err == nil {
+				t.Fatalf("Expected %q to be invalid but didn't get an error", tc.Input)
+			}
+
+			if tc.Valid && (id.ResourceGroup != "group1" || id.Path["actionGroups"] != "group1") {
+				t.Fatalf("Expected the Resource Group `group1` and Action Group `group1` but got %+v", id)
+			}
+		})
+	}
+}
+
+func TestValidateMonitorActionGroupShortName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "a",
+			ErrCount: 0,
+		},
+		{
+			Value:    "acctestag123",
+			ErrCount: 0,
+		},
+		{
+			Value:    "acctestag1234",
+			ErrCount: 1,
+		},
+		{
+			Value:    "p0action",
+			ErrCount: 0,
+		},
+		{
+			Value:    "acc-test",
+			ErrCount: 1,
+		},
+		{
+			Value:    "acc test",
+			ErrCount: 1,
+		},
+		{
+			Value:    "acc_test",
+			ErrCount: 1,
+		},
+		{
+			Value:    "acctest-ag-123",
+			ErrCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateMonitorActionGroupShortName(tc.Value, "short_name")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected the Action Group Short Name %q to trigger %d validation errors but got %d: %+v", tc.Value, tc.ErrCount, len(errors), errors)
+		}
+	}
+}
+
 func TestAccAzureRMMonitorActionGroup_requiresImport(t *testing.T) {
 	if !requireResourcesToBeImported {
 		t.Skip("Skipping since resources aren't required to be imported")
@@ -84,7 +222,7 @@ func TestAccAzureRMMonitorActionGroup_emailReceiver(t *testing.T) {
 					testCheckAzureRMMonitorActionGroupExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.0.email_address", "admin@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "admin@contoso.com"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "0"),
 				),
@@ -115,8 +253,8 @@ func TestAccAzureRMMonitorActionGroup_smsReceiver(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.country_code", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.phone_number", "1231231234"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "1231231234"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "0"),
 				),
 			},
@@ -147,7 +285,40 @@ func TestAccAzureRMMonitorActionGroup_webhookReceiver(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.0.service_uri", "http://example.com/alert"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "http://example.com/alert"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMonitorActionGroup_azureFunctionReceiver(t *testing.T) {
+	resourceName := "azurerm_monitor_action_group.test"
+	ri := tf.AccRandTimeInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMMonitorActionGroup_azureFunctionReceiver(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorActionGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorActionGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "azure_function_receiver.#", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "azure_function_receiver", "function_name", "myfunc"),
+					testCheckAzureRMMonitorActionGroupReceiverAttrSet(resourceName, "azure_function_receiver", "function_app_resource_id"),
 				),
 			},
 			{
@@ -175,16 +346,16 @@ func TestAccAzureRMMonitorActionGroup_complete(t *testing.T) {
 					testCheckAzureRMMonitorActionGroupExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.0.email_address", "admin@contoso.com"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.1.email_address", "devops@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "admin@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "devops@contoso.com"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.country_code", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.phone_number", "1231231234"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.1.country_code", "86"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.1.phone_number", "13888888888"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "1231231234"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "86"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "13888888888"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.0.service_uri", "http://example.com/alert"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.1.service_uri", "https://backup.example.com/warning"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "http://example.com/alert"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "https://backup.example.com/warning"),
 				),
 			},
 			{
@@ -196,6 +367,34 @@ func TestAccAzureRMMonitorActionGroup_complete(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMMonitorActionGroup_reorderReceivers(t *testing.T) {
+	resourceName := "azurerm_monitor_action_group.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorActionGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorActionGroup_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorActionGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "2"),
+				),
+			},
+			{
+				// reordering the receivers shouldn't result in a diff
+				Config:   testAccAzureRMMonitorActionGroup_completeReordered(ri, location),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMMonitorActionGroup_disabledUpdate(t *testing.T) {
 	resourceName := "azurerm_monitor_action_group.test"
 	ri := tf.AccRandTimeInt()
@@ -252,7 +451,7 @@ func TestAccAzureRMMonitorActionGroup_singleReceiverUpdate(t *testing.T) {
 					testCheckAzureRMMonitorActionGroupExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.0.email_address", "admin@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "admin@contoso.com"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "0"),
 				),
@@ -264,8 +463,8 @@ func TestAccAzureRMMonitorActionGroup_singleReceiverUpdate(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.country_code", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.phone_number", "1231231234"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "1231231234"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "0"),
 				),
 			},
@@ -277,7 +476,40 @@ func TestAccAzureRMMonitorActionGroup_singleReceiverUpdate(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.0.service_uri", "http://example.com/alert"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "http://example.com/alert"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMonitorActionGroup_addReceiver(t *testing.T) {
+	resourceName := "azurerm_monitor_action_group.test"
+	ri := tf.AccRandTimeInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorActionGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorActionGroup_webhookReceiver(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorActionGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMMonitorActionGroup_webhookAndEmailReceiver(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorActionGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "admin@contoso.com"),
+					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "name", "callmyapiaswell"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "http://example.com/alert"),
 				),
 			},
 		},
@@ -312,16 +544,16 @@ func TestAccAzureRMMonitorActionGroup_multipleReceiversUpdate(t *testing.T) {
 					testCheckAzureRMMonitorActionGroupExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
 					resource.TestCheckResourceAttr(resourceName, "email_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.0.email_address", "admin@contoso.com"),
-					resource.TestCheckResourceAttr(resourceName, "email_receiver.1.email_address", "devops@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "admin@contoso.com"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "email_receiver", "email_address", "devops@contoso.com"),
 					resource.TestCheckResourceAttr(resourceName, "sms_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.country_code", "1"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.0.phone_number", "1231231234"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.1.country_code", "86"),
-					resource.TestCheckResourceAttr(resourceName, "sms_receiver.1.phone_number", "13888888888"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "1"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "1231231234"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "country_code", "86"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "sms_receiver", "phone_number", "13888888888"),
 					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.0.service_uri", "http://example.com/alert"),
-					resource.TestCheckResourceAttr(resourceName, "webhook_receiver.1.service_uri", "https://backup.example.com/warning"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "http://example.com/alert"),
+					testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, "webhook_receiver", "service_uri", "https://backup.example.com/warning"),
 				),
 			},
 			{
@@ -427,6 +659,80 @@ resource "azurerm_monitor_action_group" "test" {
 `, rInt, location, rInt)
 }
 
+func testAccAzureRMMonitorActionGroup_webhookAndEmailReceiver(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  short_name          = "acctestag"
+
+  email_receiver {
+    name          = "sendtoadmin"
+    email_address = "admin@contoso.com"
+  }
+
+  webhook_receiver {
+    name        = "callmyapiaswell"
+    service_uri = "http://example.com/alert"
+  }
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMMonitorActionGroup_azureFunctionReceiver(rInt int, storage string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_app_service_plan" "test" {
+  name                = "acctestASP-%[1]d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  sku {
+    tier = "Standard"
+    size = "S1"
+  }
+}
+
+resource "azurerm_function_app" "test" {
+  name                      = "acctest-%[1]d-func"
+  location                  = "${azurerm_resource_group.test.location}"
+  resource_group_name       = "${azurerm_resource_group.test.name}"
+  app_service_plan_id       = "${azurerm_app_service_plan.test.id}"
+  storage_connection_string = "${azurerm_storage_account.test.primary_connection_string}"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%[1]d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  short_name          = "acctestag"
+
+  azure_function_receiver {
+    name                     = "funcaction"
+    function_app_resource_id = "${azurerm_function_app.test.id}"
+    function_name            = "myfunc"
+    http_trigger_url         = "https://${azurerm_function_app.test.default_hostname}/api/myfunc"
+  }
+}
+`, rInt, location, storage)
+}
+
 func testAccAzureRMMonitorActionGroup_complete(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -474,6 +780,53 @@ resource "azurerm_monitor_action_group" "test" {
 `, rInt, location, rInt)
 }
 
+func testAccAzureRMMonitorActionGroup_completeReordered(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_monitor_action_group" "test" {
+  name                = "acctestActionGroup-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  short_name          = "acctestag"
+
+  webhook_receiver {
+    name        = "callmybackupapi"
+    service_uri = "https://backup.example.com/warning"
+  }
+
+  webhook_receiver {
+    name        = "callmyapiaswell"
+    service_uri = "http://example.com/alert"
+  }
+
+  sms_receiver {
+    name         = "remotesupport"
+    country_code = "86"
+    phone_number = "13888888888"
+  }
+
+  sms_receiver {
+    name         = "oncallmsg"
+    country_code = "1"
+    phone_number = "1231231234"
+  }
+
+  email_receiver {
+    name          = "sendtodevops"
+    email_address = "devops@contoso.com"
+  }
+
+  email_receiver {
+    name          = "sendtoadmin"
+    email_address = "admin@contoso.com"
+  }
+}
+`, rInt, location, rInt)
+}
+
 func testAccAzureRMMonitorActionGroup_disabledBasic(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -545,3 +898,42 @@ func testCheckAzureRMMonitorActionGroupExists(resourceName string) resource.Test
 		return nil
 	}
 }
+
+// testCheckAzureRMMonitorActionGroupReceiverAttr checks that one of the receivers of the specified type has the
+// specified value, since receivers are stored in a Set and as such the index isn't known
+func testCheckAzureRMMonitorActionGroupReceiverAttr(resourceName, receiverType, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			segments := strings.Split(k, ".")
+			if len(segments) == 3 && segments[0] == receiverType && segments[2] == key && v == value {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%s: no `%s` has a `%s` of %q", resourceName, receiverType, key, value)
+	}
+}
+
+// testCheckAzureRMMonitorActionGroupReceiverAttrSet checks that one of the receivers of the specified type has a value for the specified key
+func testCheckAzureRMMonitorActionGroupReceiverAttrSet(resourceName, receiverType, key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			segments := strings.Split(k, ".")
+			if len(segments) == 3 && segments[0] == receiverType && segments[2] == key && v != "" {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%s: no `%s` has a value for `%s`", resourceName, receiverType, key)
+	}
+}
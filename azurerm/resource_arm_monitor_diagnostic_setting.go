@@ -28,6 +28,13 @@ func resourceArmMonitorDiagnosticSetting() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -156,7 +163,13 @@ func resourceArmMonitorDiagnosticSetting() *schema.Resource {
 
 func resourceArmMonitorDiagnosticSettingCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
-	ctx := meta.(*ArmClient).StopContext
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, timeout)
+	defer cancel()
 	log.Printf("[INFO] preparing arguments for Azure ARM Diagnostic Settings.")
 
 	name := d.Get("name").(string)
@@ -240,7 +253,12 @@ func resourceArmMonitorDiagnosticSettingCreateUpdate(d *schema.ResourceData, met
 
 	// the Azure SDK prefixes the URI with a `/` such this makes a bad request if we don't trim the `/`
 	targetResourceId := strings.TrimPrefix(actualResourceId, "/")
-	if _, err := client.CreateOrUpdate(ctx, targetResourceId, properties, name); err != nil {
+
+	// the target resource can take a few minutes to accept Diagnostic Settings once it's been created
+	err := resource.Retry(timeout, retryMonitorDiagnosticSettingCreate(func() (insights.DiagnosticSettingsResource, error) {
+		return client.CreateOrUpdate(ctx, targetResourceId, properties, name)
+	}))
+	if err != nil {
 		return fmt.Errorf("Error creating Monitor Diagnostics Setting %q for Resource %q: %+v", name, actualResourceId, err)
 	}
 
@@ -259,7 +277,8 @@ func resourceArmMonitorDiagnosticSettingCreateUpdate(d *schema.ResourceData, met
 
 func resourceArmMonitorDiagnosticSettingRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutRead))
+	defer cancel()
 
 	id, err := parseMonitorDiagnosticId(d.Id())
 	if err != nil {
@@ -300,7 +319,8 @@ func resourceArmMonitorDiagnosticSettingRead(d *schema.ResourceData, meta interf
 
 func resourceArmMonitorDiagnosticSettingDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).monitorDiagnosticSettingsClient
-	ctx := meta.(*ArmClient).StopContext
+	ctx, cancel := context.WithTimeout(meta.(*ArmClient).StopContext, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
 
 	id, err := parseMonitorDiagnosticId(d.Id())
 	if err != nil {
@@ -346,6 +366,29 @@ func monitorDiagnosticSettingDeletedRefreshFunc(ctx context.Context, client insi
 	}
 }
 
+func retryMonitorDiagnosticSettingCreate(create func() (insights.DiagnosticSettingsResource, error)) func() *resource.RetryError {
+	return func() *resource.RetryError {
+		if _, err := create(); err != nil {
+			if utils.ResponseErrorIsRetryable(err) || monitorDiagnosticSettingTargetIsNotReady(err) {
+				log.Printf("[DEBUG] Target Resource isn't ready for Diagnostic Settings yet - retrying: %+v", err)
+				return resource.RetryableError(err)
+			}
+
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	}
+}
+
+// monitorDiagnosticSettingTargetIsNotReady returns whether the error means the target resource exists but hasn't finished
+// provisioning, which happens when it's been created immediately beforehand. A 404 (e.g. `ResourceNotFound`) isn't retried,
+// since that's also returned when the target or destination ID is wrong - which should fail immediately
+func monitorDiagnosticSettingTargetIsNotReady(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "is not ready") || strings.Contains(message, "resourcenotready")
+}
+
 func expandMonitorDiagnosticsSettingsLogs(input []interface{}) []insights.LogSettings {
 	results := make([]insights.LogSettings, 0)
 
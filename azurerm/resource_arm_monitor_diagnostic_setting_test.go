@@ -2,15 +2,74 @@ package azurerm
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+func TestRetryMonitorDiagnosticSettingCreate(t *testing.T) {
+	responseWithStatusCode := func(statusCode int) insights.DiagnosticSettingsResource {
+		return insights.DiagnosticSettingsResource{
+			Response: autorest.Response{
+				Response: &http.Response{
+					StatusCode: statusCode,
+				},
+			},
+		}
+	}
+
+	// the target resource isn't ready for the first few attempts..
+	calls := 0
+	delayed := func() (insights.DiagnosticSettingsResource, error) {
+		calls++
+		if calls < 3 {
+			return responseWithStatusCode(http.StatusConflict), fmt.Errorf("Resource 'example' is not ready")
+		}
+
+		return responseWithStatusCode(http.StatusOK), nil
+	}
+	if err := resource.Retry(time.Minute, retryMonitorDiagnosticSettingCreate(delayed)); err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("Expected 3 attempts but got %d", calls)
+	}
+
+	// ..but a missing target (or destination) isn't retried, since the ID may be wrong..
+	calls = 0
+	missing := func() (insights.DiagnosticSettingsResource, error) {
+		calls++
+		return responseWithStatusCode(http.StatusNotFound), fmt.Errorf("ResourceNotFound")
+	}
+	if err := resource.Retry(time.Minute, retryMonitorDiagnosticSettingCreate(missing)); err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 attempt but got %d", calls)
+	}
+
+	// ..and other errors aren't retried
+	calls = 0
+	invalid := func() (insights.DiagnosticSettingsResource, error) {
+		calls++
+		return responseWithStatusCode(http.StatusBadRequest), fmt.Errorf("Category 'Example' is not supported")
+	}
+	if err := resource.Retry(time.Minute, retryMonitorDiagnosticSettingCreate(invalid)); err == nil {
+		t.Fatalf("Expected an error but didn't get one")
+	}
+	if calls != 1 {
+		t.Fatalf("Expected 1 attempt but got %d", calls)
+	}
+}
+
 func TestAccAzureRMMonitorDiagnosticSetting_eventhub(t *testing.T) {
 	resourceName := "azurerm_monitor_diagnostic_setting.test"
 	ri := acctest.RandIntRange(10000, 99999)
@@ -2,10 +2,14 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2018-03-01/insights"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -27,6 +31,8 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: resourceArmMonitorMetricAlertCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -37,14 +43,10 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 
 			"resource_group_name": resourceGroupNameSchema(),
 
-			// TODO: Multiple resource IDs (Remove MaxItems) support is missing in SDK
-			// Issue to track: https://github.com/Azure/azure-sdk-for-go/issues/2920
-			// But to prevent potential state migration in the future, let's stick to use Set now
 			"scopes": {
 				Type:     schema.TypeSet,
 				Required: true,
 				MinItems: 1,
-				MaxItems: 1,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
 					ValidateFunc: azure.ValidateResourceID,
@@ -58,6 +60,12 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 				MinItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
 						"metric_namespace": {
 							Type:         schema.TypeString,
 							Required:     true,
@@ -149,10 +157,11 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 				Set: resourceArmMonitorMetricAlertActionHash,
 			},
 
+			// when omitted Azure's default is used, which is returned from the API
 			"auto_mitigate": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				Default:  false,
+				Computed: true,
 			},
 
 			"description": {
@@ -202,11 +211,219 @@ func resourceArmMonitorMetricAlert() *schema.Resource {
 				}, false),
 			},
 
+			"target_resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"target_resource_location": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				StateFunc:        azureRMNormalizeLocation,
+				DiffSuppressFunc: azureRMSuppressLocationDiff,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
 }
 
+// monitorMetricAlertSupportedAggregations contains the aggregations supported by common metrics, keyed by
+// Metric Namespace and then Metric Name - new namespaces and metrics can be added here as required
+var monitorMetricAlertSupportedAggregations = map[string]map[string][]string{
+	"Microsoft.Compute/virtualMachines": {
+		"Percentage CPU":         {"Average", "Minimum", "Maximum"},
+		"Available Memory Bytes": {"Average", "Minimum", "Maximum"},
+		"Network In Total":       {"Total"},
+		"Network Out Total":      {"Total"},
+	},
+	"Microsoft.Storage/storageAccounts": {
+		"Availability":         {"Average", "Minimum", "Maximum"},
+		"Egress":               {"Total", "Average", "Minimum", "Maximum"},
+		"Ingress":              {"Total", "Average", "Minimum", "Maximum"},
+		"SuccessE2ELatency":    {"Average", "Minimum", "Maximum"},
+		"SuccessServerLatency": {"Average", "Minimum", "Maximum"},
+	},
+}
+
+// monitorMetricAlertSupportedWindowSizes contains the window sizes which can be used with each frequency
+var monitorMetricAlertSupportedWindowSizes = map[string][]string{
+	"PT1M":  {"PT1M", "PT5M", "PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT5M":  {"PT5M", "PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT15M": {"PT15M", "PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT30M": {"PT30M", "PT1H", "PT6H", "PT12H", "P1D"},
+	"PT1H":  {"PT1H", "PT6H", "PT12H", "P1D"},
+}
+
+func resourceArmMonitorMetricAlertCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// values which aren't known yet (e.g. interpolated from another resource) are validated at apply time
+	if d.NewValueKnown("frequency") && d.NewValueKnown("window_size") {
+		if err := validateMonitorMetricAlertWindowSize(d.Get("frequency").(string), d.Get("window_size").(string)); err != nil {
+			return err
+		}
+	}
+
+	// a `target_resource_type` which was derived from the previous `scopes` needs to be derived again when they change
+	if d.Id() != "" && d.HasChange("scopes") && d.NewValueKnown("scopes") && !d.HasChange("target_resource_type") {
+		oldScopes, newScopes := d.GetChange("scopes")
+		existing := d.Get("target_resource_type").(string)
+		targetResourceType, changed := monitorMetricAlertTargetResourceTypeForNewScopes(existing, oldScopes.(*schema.Set).List(), newScopes.(*schema.Set).List())
+		if changed {
+			if err := d.SetNew("target_resource_type", targetResourceType); err != nil {
+				return err
+			}
+		}
+	}
+
+	// likewise a `target_resource_location` which was looked up from the previous `scopes` needs to be looked up again
+	// during the apply, since the new `scopes` may be in a different location
+	if d.Id() != "" && d.HasChange("scopes") && !d.HasChange("target_resource_location") {
+		oldScopes, _ := d.GetChange("scopes")
+		existing := d.Get("target_resource_location").(string)
+		client := meta.(*ArmClient).resourcesClient
+		ctx := meta.(*ArmClient).StopContext
+		derived := monitorMetricAlertTargetResourceLocationIsDerived(existing, oldScopes.(*schema.Set).List(), func(resourceId string) (string, error) {
+			return monitorMetricAlertResourceLocation(ctx, client, resourceId)
+		})
+		if derived {
+			if err := d.SetNewComputed("target_resource_location"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !d.NewValueKnown("criteria") {
+		return nil
+	}
+
+	criteriaRaw := d.Get("criteria").([]interface{})
+	if err := validateMonitorMetricAlertCriteriaNames(criteriaRaw); err != nil {
+		return err
+	}
+
+	for _, v := range criteriaRaw {
+		if v == nil {
+			continue
+		}
+
+		criteria := v.(map[string]interface{})
+		namespace := criteria["metric_namespace"].(string)
+		metricName := criteria["metric_name"].(string)
+		aggregation := criteria["aggregation"].(string)
+
+		if err := validateMonitorMetricAlertAggregation(namespace, metricName, aggregation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// monitorMetricAlertCriteriaName returns the name of the criteria, which defaults to `Metric{N}` when it's not specified
+func monitorMetricAlertCriteriaName(input map[string]interface{}, index int) string {
+	if name, ok := input["name"].(string); ok && name != "" {
+		return name
+	}
+
+	return fmt.Sprintf("Metric%d", index+1)
+}
+
+// validateMonitorMetricAlertCriteriaNames returns an error when more than one criteria has the same name, since
+// all of the criteria need to be met for the alert to fire and the API identifies each criteria by its name
+func validateMonitorMetricAlertCriteriaNames(input []interface{}) error {
+	names := make(map[string]int)
+	for i, item := range input {
+		if item == nil {
+			continue
+		}
+
+		name := monitorMetricAlertCriteriaName(item.(map[string]interface{}), i)
+		if existing, ok := names[strings.ToLower(name)]; ok {
+			return fmt.Errorf("`criteria` names must be unique but criteria %d and %d are both named %q", existing, i, name)
+		}
+		names[strings.ToLower(name)] = i
+	}
+
+	return nil
+}
+
+// sortMonitorMetricAlertCriteria orders the criteria returned from the API to match the order in which they're defined, since
+// the API doesn't guarantee the order is preserved - criteria which aren't defined (e.g. added outside of Terraform) come last
+func sortMonitorMetricAlertCriteria(input []interface{}, existing []interface{}) []interface{} {
+	positions := make(map[string]int)
+	for i, item := range existing {
+		if item == nil {
+			continue
+		}
+
+		positions[strings.ToLower(monitorMetricAlertCriteriaName(item.(map[string]interface{}), i))] = i
+	}
+
+	result := make([]interface{}, len(input))
+	copy(result, input)
+	sort.SliceStable(result, func(i, j int) bool {
+		iPosition, iExists := positions[strings.ToLower(result[i].(map[string]interface{})["name"].(string))]
+		jPosition, jExists := positions[strings.ToLower(result[j].(map[string]interface{})["name"].(string))]
+		if iExists && jExists {
+			return iPosition < jPosition
+		}
+
+		return iExists && !jExists
+	})
+
+	return result
+}
+
+// validateMonitorMetricAlertAggregation returns an error when the aggregation is known to be unsupported by the metric -
+// since this is best-effort, metrics which aren't in `monitorMetricAlertSupportedAggregations` are allowed with a warning
+func validateMonitorMetricAlertAggregation(namespace, metricName, aggregation string) error {
+	if namespace == "" || metricName == "" || aggregation == "" {
+		return nil
+	}
+
+	for knownNamespace, metrics := range monitorMetricAlertSupportedAggregations {
+		if !strings.EqualFold(knownNamespace, namespace) {
+			continue
+		}
+
+		for knownMetric, aggregations := range metrics {
+			if !strings.EqualFold(knownMetric, metricName) {
+				continue
+			}
+
+			for _, supported := range aggregations {
+				if strings.EqualFold(supported, aggregation) {
+					return nil
+				}
+			}
+
+			return fmt.Errorf("The aggregation %q isn't supported by the metric %q in the namespace %q - supported aggregations are: %s", aggregation, metricName, namespace, strings.Join(aggregations, ", "))
+		}
+	}
+
+	log.Printf("[WARN] Unable to validate the aggregation %q for the metric %q in the namespace %q since the supported aggregations aren't known", aggregation, metricName, namespace)
+	return nil
+}
+
+// validateMonitorMetricAlertWindowSize returns an error when the window size can't be used with the frequency
+func validateMonitorMetricAlertWindowSize(frequency, windowSize string) error {
+	windowSizes, ok := monitorMetricAlertSupportedWindowSizes[frequency]
+	if !ok || windowSize == "" {
+		return nil
+	}
+
+	for _, supported := range windowSizes {
+		if supported == windowSize {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The `window_size` %q can't be used with the `frequency` %q - supported values for `window_size` are: %s", windowSize, frequency, strings.Join(windowSizes, ", "))
+}
+
 func resourceArmMonitorMetricAlertCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).monitorMetricAlertsClient
 	ctx := meta.(*ArmClient).StopContext
@@ -228,7 +445,6 @@ func resourceArmMonitorMetricAlertCreateUpdate(d *schema.ResourceData, meta inte
 	}
 
 	enabled := d.Get("enabled").(bool)
-	autoMitigate := d.Get("auto_mitigate").(bool)
 	description := d.Get("description").(string)
 	scopesRaw := d.Get("scopes").(*schema.Set).List()
 	severity := d.Get("severity").(int)
@@ -240,22 +456,59 @@ func resourceArmMonitorMetricAlertCreateUpdate(d *schema.ResourceData, meta inte
 	tags := d.Get("tags").(map[string]interface{})
 	expandedTags := expandTags(tags)
 
+	scopes := utils.ExpandStringArray(scopesRaw)
+
+	// when the `target_resource_type` isn't specified we derive it from the Resource ID's within `scopes`
+	// (when the `scopes` change, it's re-derived during the plan - see the CustomizeDiff)
+	targetResourceType := d.Get("target_resource_type").(string)
+	if targetResourceType == "" {
+		derivedResourceType, err := monitorMetricAlertTargetResourceType(*scopes)
+		if err != nil {
+			return err
+		}
+		targetResourceType = derivedResourceType
+	}
+
+	// the region is only required when alerting on multiple resources - and can't be derived from the Resource ID,
+	// so when it isn't specified it's looked up, providing all of the resources are in the same location
+	// (when the `scopes` change, a region which was looked up is cleared during the plan - see the CustomizeDiff)
+	multipleResources := len(*scopes) > 1
+	targetResourceLocation := d.Get("target_resource_location").(string)
+	if multipleResources && targetResourceLocation == "" {
+		resourcesClient := meta.(*ArmClient).resourcesClient
+		derivedLocation, err := monitorMetricAlertTargetResourceLocation(*scopes, func(resourceId string) (string, error) {
+			return monitorMetricAlertResourceLocation(ctx, resourcesClient, resourceId)
+		})
+		if err != nil {
+			return err
+		}
+		targetResourceLocation = derivedLocation
+	}
+
 	parameters := insights.MetricAlertResource{
 		Location: utils.String(azureRMNormalizeLocation("Global")),
 		MetricAlertProperties: &insights.MetricAlertProperties{
 			Enabled:             utils.Bool(enabled),
-			AutoMitigate:        utils.Bool(autoMitigate),
 			Description:         utils.String(description),
 			Severity:            utils.Int32(int32(severity)),
 			EvaluationFrequency: utils.String(frequency),
 			WindowSize:          utils.String(windowSize),
-			Scopes:              utils.ExpandStringArray(scopesRaw),
-			Criteria:            expandMonitorMetricAlertCriteria(criteriaRaw),
+			Scopes:              scopes,
+			Criteria:            expandMonitorMetricAlertCriteria(criteriaRaw, multipleResources),
 			Actions:             expandMonitorMetricAlertAction(actionRaw),
+			TargetResourceType:  utils.String(targetResourceType),
 		},
 		Tags: expandedTags,
 	}
 
+	if v, ok := d.GetOkExists("auto_mitigate"); ok {
+		parameters.MetricAlertProperties.AutoMitigate = utils.Bool(v.(bool))
+	}
+
+	if multipleResources {
+		parameters.MetricAlertProperties.TargetResourceRegion = utils.String(azureRMNormalizeLocation(targetResourceLocation))
+	}
+
 	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, parameters); err != nil {
 		return fmt.Errorf("Error creating or updating metric alert %q (resource group %q): %+v", name, resourceGroup, err)
 	}
@@ -297,7 +550,7 @@ func resourceArmMonitorMetricAlertRead(d *schema.ResourceData, meta interface{})
 	d.Set("resource_group_name", resourceGroup)
 	if alert := resp.MetricAlertProperties; alert != nil {
 		d.Set("enabled", alert.Enabled)
-		d.Set("auto_mitigate", alert.AutoMitigate)
+		d.Set("auto_mitigate", flattenMonitorMetricAlertAutoMitigate(alert.AutoMitigate))
 		d.Set("description", alert.Description)
 		d.Set("severity", alert.Severity)
 		d.Set("frequency", alert.EvaluationFrequency)
@@ -305,12 +558,17 @@ func resourceArmMonitorMetricAlertRead(d *schema.ResourceData, meta interface{})
 		if err := d.Set("scopes", utils.FlattenStringArray(alert.Scopes)); err != nil {
 			return fmt.Errorf("Error setting `scopes`: %+v", err)
 		}
-		if err := d.Set("criteria", flattenMonitorMetricAlertCriteria(alert.Criteria)); err != nil {
+		criteria := sortMonitorMetricAlertCriteria(flattenMonitorMetricAlertCriteria(alert.Criteria), d.Get("criteria").([]interface{}))
+		if err := d.Set("criteria", criteria); err != nil {
 			return fmt.Errorf("Error setting `criteria`: %+v", err)
 		}
 		if err := d.Set("action", flattenMonitorMetricAlertAction(alert.Actions)); err != nil {
 			return fmt.Errorf("Error setting `action`: %+v", err)
 		}
+		d.Set("target_resource_type", alert.TargetResourceType)
+		if region := alert.TargetResourceRegion; region != nil {
+			d.Set("target_resource_location", azureRMNormalizeLocation(*region))
+		}
 	}
 	flattenAndSetTags(d, resp.Tags)
 
@@ -337,7 +595,118 @@ func resourceArmMonitorMetricAlertDelete(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func expandMonitorMetricAlertCriteria(input []interface{}) *insights.MetricAlertSingleResourceMultipleMetricCriteria {
+func monitorMetricAlertTargetResourceType(scopes []string) (string, error) {
+	targetResourceType := ""
+	for _, scope := range scopes {
+		resourceType, err := azure.ParseAzureResourceType(scope)
+		if err != nil {
+			return "", fmt.Errorf("Error determining the Resource Type for %q: %+v", scope, err)
+		}
+
+		if targetResourceType == "" {
+			targetResourceType = resourceType
+			continue
+		}
+
+		if !strings.EqualFold(targetResourceType, resourceType) {
+			return "", fmt.Errorf("`target_resource_type` must be specified when `scopes` contains Resources of different types (%q and %q)", targetResourceType, resourceType)
+		}
+	}
+
+	return targetResourceType, nil
+}
+
+// monitorMetricAlertTargetResourceTypeForNewScopes returns the `target_resource_type` derived from the new `scopes`, and
+// whether it should be used - which is only when the existing value was derived from the previous `scopes`, since
+// otherwise it was specified (for example when alerting on all of the Virtual Machines within a Resource Group)
+func monitorMetricAlertTargetResourceTypeForNewScopes(existing string, oldScopes []interface{}, newScopes []interface{}) (string, bool) {
+	previous, err := monitorMetricAlertTargetResourceType(*utils.ExpandStringArray(oldScopes))
+	if err != nil || !strings.EqualFold(previous, existing) {
+		return existing, false
+	}
+
+	derived, err := monitorMetricAlertTargetResourceType(*utils.ExpandStringArray(newScopes))
+	if err != nil {
+		// this'll be surfaced when the Resource Type is derived during the apply
+		return "", true
+	}
+
+	return derived, !strings.EqualFold(derived, existing)
+}
+
+// monitorMetricAlertTargetResourceLocation returns the location shared by all of the Resources within `scopes`,
+// returning an error when they're in different locations (since then the location has to be specified)
+func monitorMetricAlertTargetResourceLocation(scopes []string, getLocation func(resourceId string) (string, error)) (string, error) {
+	targetResourceLocation := ""
+	for _, scope := range scopes {
+		location, err := getLocation(scope)
+		if err != nil {
+			return "", fmt.Errorf("Error determining the location of %q (specify the `target_resource_location` to skip this lookup): %+v", scope, err)
+		}
+
+		if targetResourceLocation == "" {
+			targetResourceLocation = location
+			continue
+		}
+
+		if azureRMNormalizeLocation(targetResourceLocation) != azureRMNormalizeLocation(location) {
+			return "", fmt.Errorf("`target_resource_location` must be specified when `scopes` contains Resources in different locations (%q and %q)", targetResourceLocation, location)
+		}
+	}
+
+	return azureRMNormalizeLocation(targetResourceLocation), nil
+}
+
+// monitorMetricAlertTargetResourceLocationIsDerived returns whether the existing `target_resource_location` is the
+// location of the previous `scopes` - in which case it was looked up rather than specified. When the previous `scopes`
+// can't be looked up this returns false, so that a specified location is never discarded
+func monitorMetricAlertTargetResourceLocationIsDerived(existing string, oldScopes []interface{}, getLocation func(resourceId string) (string, error)) bool {
+	if existing == "" {
+		return false
+	}
+
+	previous, err := monitorMetricAlertTargetResourceLocation(*utils.ExpandStringArray(oldScopes), getLocation)
+	if err != nil {
+		return false
+	}
+
+	return azureRMNormalizeLocation(previous) == azureRMNormalizeLocation(existing)
+}
+
+// monitorMetricAlertResourceLocation looks up the location of a Resource by listing the Resources of that type within its
+// Resource Group - since this works for any type of Resource, rather than needing the API Version for each Resource Provider
+func monitorMetricAlertResourceLocation(ctx context.Context, client resources.Client, resourceId string) (string, error) {
+	id, err := parseAzureResourceID(resourceId)
+	if err != nil {
+		return "", err
+	}
+
+	resourceType, err := azure.ParseAzureResourceType(resourceId)
+	if err != nil {
+		return "", err
+	}
+
+	filter := fmt.Sprintf("resourceType eq '%s'", resourceType)
+	iterator, err := client.ListByResourceGroupComplete(ctx, id.ResourceGroup, filter, "", nil)
+	if err != nil {
+		return "", fmt.Errorf("Error listing Resources of type %q (Resource Group %q): %+v", resourceType, id.ResourceGroup, err)
+	}
+
+	for iterator.NotDone() {
+		v := iterator.Value()
+		if v.ID != nil && strings.EqualFold(*v.ID, resourceId) && v.Location != nil {
+			return *v.Location, nil
+		}
+
+		if err := iterator.NextWithContext(ctx); err != nil {
+			return "", fmt.Errorf("Error listing Resources of type %q (Resource Group %q): %+v", resourceType, id.ResourceGroup, err)
+		}
+	}
+
+	return "", fmt.Errorf("Resource %q was not found", resourceId)
+}
+
+func expandMonitorMetricAlertCriteria(input []interface{}, multipleResources bool) insights.BasicMetricAlertCriteria {
 	criteria := make([]insights.MetricCriteria, 0)
 	for i, item := range input {
 		v := item.(map[string]interface{})
@@ -353,7 +722,7 @@ func expandMonitorMetricAlertCriteria(input []interface{}) *insights.MetricAlert
 		}
 
 		criteria = append(criteria, insights.MetricCriteria{
-			Name:            utils.String(fmt.Sprintf("Metric%d", i+1)),
+			Name:            utils.String(monitorMetricAlertCriteriaName(v, i)),
 			MetricNamespace: utils.String(v["metric_namespace"].(string)),
 			MetricName:      utils.String(v["metric_name"].(string)),
 			TimeAggregation: v["aggregation"].(string),
@@ -362,6 +731,20 @@ func expandMonitorMetricAlertCriteria(input []interface{}) *insights.MetricAlert
 			Dimensions:      &dimensions,
 		})
 	}
+
+	if multipleResources {
+		multiMetricCriteria := make([]insights.BasicMultiMetricCriteria, 0)
+		for _, item := range criteria {
+			item.CriterionType = insights.CriterionTypeStaticThresholdCriterion
+			multiMetricCriteria = append(multiMetricCriteria, item)
+		}
+
+		return &insights.MetricAlertMultipleResourceMultipleMetricCriteria{
+			AllOf:     &multiMetricCriteria,
+			OdataType: insights.OdataTypeMicrosoftAzureMonitorMultipleResourceMultipleMetricCriteria,
+		}
+	}
+
 	return &insights.MetricAlertSingleResourceMultipleMetricCriteria{
 		AllOf:     &criteria,
 		OdataType: insights.OdataTypeMicrosoftAzureMonitorSingleResourceMultipleMetricCriteria,
@@ -394,13 +777,27 @@ func flattenMonitorMetricAlertCriteria(input insights.BasicMetricAlertCriteria)
 	if input == nil {
 		return
 	}
-	criteria, ok := input.AsMetricAlertSingleResourceMultipleMetricCriteria()
-	if !ok || criteria == nil || criteria.AllOf == nil {
-		return
+
+	metrics := make([]insights.MetricCriteria, 0)
+	if criteria, ok := input.AsMetricAlertSingleResourceMultipleMetricCriteria(); ok && criteria != nil && criteria.AllOf != nil {
+		metrics = *criteria.AllOf
 	}
-	for _, metric := range *criteria.AllOf {
+	if criteria, ok := input.AsMetricAlertMultipleResourceMultipleMetricCriteria(); ok && criteria != nil && criteria.AllOf != nil {
+		for _, item := range *criteria.AllOf {
+			if metric, ok := item.AsMetricCriteria(); ok && metric != nil {
+				metrics = append(metrics, *metric)
+			}
+		}
+	}
+
+	for _, metric := range metrics {
 		v := make(map[string]interface{})
 
+		name := ""
+		if metric.Name != nil {
+			name = *metric.Name
+		}
+		v["name"] = name
 		if metric.MetricNamespace != nil {
 			v["metric_namespace"] = *metric.MetricNamespace
 		}
@@ -471,3 +868,12 @@ func resourceArmMonitorMetricAlertActionHash(input interface{}) int {
 	}
 	return hashcode.String(buf.String())
 }
+
+func flattenMonitorMetricAlertAutoMitigate(input *bool) bool {
+	// the API omits `autoMitigate` when it's using the default, which is `true`
+	if input == nil {
+		return true
+	}
+
+	return *input
+}
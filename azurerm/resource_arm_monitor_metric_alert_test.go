@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestAccAzureRMMonitorMetricAlert_basic(t *testing.T) {
@@ -47,6 +48,623 @@ func TestAccAzureRMMonitorMetricAlert_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMMonitorMetricAlert_multipleScopes(t *testing.T) {
+	resourceName := "azurerm_monitor_metric_alert.test"
+	ri := tf.AccRandTimeInt()
+	rs := strings.ToLower(acctest.RandString(10))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorMetricAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorMetricAlert_multipleScopes(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "target_resource_type", "Microsoft.Storage/storageAccounts"),
+					resource.TestCheckResourceAttr(resourceName, "target_resource_location", azureRMNormalizeLocation(location)),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMMonitorMetricAlert_multipleScopesUpdateLocation(t *testing.T) {
+	resourceName := "azurerm_monitor_metric_alert.test"
+	ri := tf.AccRandTimeInt()
+	rs := strings.ToLower(acctest.RandString(10))
+	location := testLocation()
+	altLocation := testAltLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorMetricAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorMetricAlert_multipleScopesDerivedLocation(ri, rs, location, altLocation, "test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "target_resource_location", azureRMNormalizeLocation(location)),
+				),
+			},
+			{
+				Config: testAccAzureRMMonitorMetricAlert_multipleScopesDerivedLocation(ri, rs, location, altLocation, "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "target_resource_location", azureRMNormalizeLocation(altLocation)),
+				),
+			},
+		},
+	})
+}
+
+func TestMonitorMetricAlertTargetResourceType(t *testing.T) {
+	cases := []struct {
+		Scopes       []string
+		ExpectedType string
+		ExpectError  bool
+	}{
+		{
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1",
+			},
+			ExpectedType: "Microsoft.Compute/virtualMachines",
+		},
+		{
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2/providers/microsoft.compute/virtualmachines/vm2",
+			},
+			ExpectedType: "Microsoft.Compute/virtualMachines",
+		},
+		{
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			},
+			ExpectError: true,
+		},
+		{
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		resourceType, err := monitorMetricAlertTargetResourceType(tc.Scopes)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Fatalf("Unexpected error for %+v: %+v", tc.Scopes, err)
+			}
+			continue
+		}
+
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for %+v but didn't get one", tc.Scopes)
+		}
+
+		if resourceType != tc.ExpectedType {
+			t.Fatalf("Expected %q but got %q", tc.ExpectedType, resourceType)
+		}
+	}
+}
+
+func TestMonitorMetricAlertTargetResourceTypeForNewScopes(t *testing.T) {
+	vm1 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1"
+	vm2 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm2"
+	account1 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"
+	group1 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1"
+
+	cases := []struct {
+		Name            string
+		Existing        string
+		OldScopes       []interface{}
+		NewScopes       []interface{}
+		ExpectedType    string
+		ExpectedChanged bool
+	}{
+		{
+			Name:            "derived and the type is unchanged",
+			Existing:        "Microsoft.Compute/virtualMachines",
+			OldScopes:       []interface{}{vm1},
+			NewScopes:       []interface{}{vm1, vm2},
+			ExpectedType:    "Microsoft.Compute/virtualMachines",
+			ExpectedChanged: false,
+		},
+		{
+			Name:            "derived and the type has changed",
+			Existing:        "Microsoft.Compute/virtualMachines",
+			OldScopes:       []interface{}{vm1},
+			NewScopes:       []interface{}{account1},
+			ExpectedType:    "Microsoft.Storage/storageAccounts",
+			ExpectedChanged: true,
+		},
+		{
+			Name:            "derived and the new scopes are of different types",
+			Existing:        "Microsoft.Compute/virtualMachines",
+			OldScopes:       []interface{}{vm1},
+			NewScopes:       []interface{}{vm1, account1},
+			ExpectedType:    "",
+			ExpectedChanged: true,
+		},
+		{
+			// e.g. alerting on all of the Virtual Machines within a Resource Group
+			Name:            "specified",
+			Existing:        "Microsoft.Compute/virtualMachines",
+			OldScopes:       []interface{}{group1},
+			NewScopes:       []interface{}{account1},
+			ExpectedType:    "Microsoft.Compute/virtualMachines",
+			ExpectedChanged: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, changed := monitorMetricAlertTargetResourceTypeForNewScopes(tc.Existing, tc.OldScopes, tc.NewScopes)
+			if actual != tc.ExpectedType || changed != tc.ExpectedChanged {
+				t.Fatalf("Expected %q (changed %t) but got %q (changed %t)", tc.ExpectedType, tc.ExpectedChanged, actual, changed)
+			}
+		})
+	}
+}
+
+func TestMonitorMetricAlertTargetResourceLocation(t *testing.T) {
+	locations := map[string]string{
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1": "westeurope",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2/providers/Microsoft.Storage/storageAccounts/account2": "West Europe",
+		"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group3/providers/Microsoft.Storage/storageAccounts/account3": "eastus",
+	}
+	getLocation := func(resourceId string) (string, error) {
+		if location, ok := locations[resourceId]; ok {
+			return location, nil
+		}
+
+		return "", fmt.Errorf("Resource %q was not found", resourceId)
+	}
+
+	cases := []struct {
+		Name             string
+		Scopes           []string
+		ExpectedLocation string
+		ExpectError      bool
+	}{
+		{
+			Name: "single scope",
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			},
+			ExpectedLocation: "westeurope",
+		},
+		{
+			Name: "multiple scopes in the same location",
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2/providers/Microsoft.Storage/storageAccounts/account2",
+			},
+			ExpectedLocation: "westeurope",
+		},
+		{
+			Name: "multiple scopes in different locations",
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group3/providers/Microsoft.Storage/storageAccounts/account3",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "missing resource",
+			Scopes: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group4/providers/Microsoft.Storage/storageAccounts/account4",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			location, err := monitorMetricAlertTargetResourceLocation(tc.Scopes, getLocation)
+			if (err != nil) != tc.ExpectError {
+				t.Fatalf("Expected an error %t but got %+v", tc.ExpectError, err)
+			}
+
+			if location != tc.ExpectedLocation {
+				t.Fatalf("Expected the location %q but got %q", tc.ExpectedLocation, location)
+			}
+		})
+	}
+}
+
+func TestMonitorMetricAlertTargetResourceLocationIsDerived(t *testing.T) {
+	account1 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"
+	account2 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account2"
+	account3 := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2/providers/Microsoft.Storage/storageAccounts/account3"
+	locations := map[string]string{
+		account1: "westeurope",
+		account2: "West Europe",
+		account3: "eastus",
+	}
+	getLocation := func(resourceId string) (string, error) {
+		if location, ok := locations[resourceId]; ok {
+			return location, nil
+		}
+
+		return "", fmt.Errorf("Resource %q was not found", resourceId)
+	}
+
+	cases := []struct {
+		Name      string
+		Existing  string
+		OldScopes []interface{}
+		Expected  bool
+	}{
+		{
+			Name:      "not set",
+			Existing:  "",
+			OldScopes: []interface{}{account1, account2},
+			Expected:  false,
+		},
+		{
+			Name:      "looked up from the previous scopes",
+			Existing:  "westeurope",
+			OldScopes: []interface{}{account1, account2},
+			Expected:  true,
+		},
+		{
+			Name:      "looked up from the previous scopes with different casing",
+			Existing:  "WestEurope",
+			OldScopes: []interface{}{account1, account2},
+			Expected:  true,
+		},
+		{
+			Name:      "specified",
+			Existing:  "northeurope",
+			OldScopes: []interface{}{account1, account2},
+			Expected:  false,
+		},
+		{
+			Name:      "previous scopes in different locations",
+			Existing:  "westeurope",
+			OldScopes: []interface{}{account1, account3},
+			Expected:  false,
+		},
+		{
+			Name:      "previous scopes can't be looked up",
+			Existing:  "westeurope",
+			OldScopes: []interface{}{account1, "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group3/providers/Microsoft.Storage/storageAccounts/account4"},
+			Expected:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if actual := monitorMetricAlertTargetResourceLocationIsDerived(tc.Existing, tc.OldScopes, getLocation); actual != tc.Expected {
+				t.Fatalf("Expected %t but got %t", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccAzureRMMonitorMetricAlert_autoMitigate(t *testing.T) {
+	resourceName := "azurerm_monitor_metric_alert.test"
+	ri := tf.AccRandTimeInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorMetricAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				// omitted, so Azure's default is used
+				Config: testAccAzureRMMonitorMetricAlert_basic(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "true"),
+				),
+			},
+			{
+				Config:   testAccAzureRMMonitorMetricAlert_basic(ri, rs, location),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAzureRMMonitorMetricAlert_autoMitigate(ri, rs, location, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "false"),
+				),
+			},
+			{
+				Config:   testAccAzureRMMonitorMetricAlert_autoMitigate(ri, rs, location, false),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAzureRMMonitorMetricAlert_autoMitigate(ri, rs, location, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "true"),
+				),
+			},
+			{
+				Config:   testAccAzureRMMonitorMetricAlert_autoMitigate(ri, rs, location, true),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestFlattenMonitorMetricAlertAutoMitigate(t *testing.T) {
+	if !flattenMonitorMetricAlertAutoMitigate(nil) {
+		t.Fatalf("Expected an omitted `autoMitigate` to be Azure's default of `true`")
+	}
+
+	if !flattenMonitorMetricAlertAutoMitigate(utils.Bool(true)) {
+		t.Fatalf("Expected `autoMitigate` to be `true`")
+	}
+
+	if flattenMonitorMetricAlertAutoMitigate(utils.Bool(false)) {
+		t.Fatalf("Expected `autoMitigate` to be `false`")
+	}
+}
+
+func TestValidateMonitorMetricAlertAggregation(t *testing.T) {
+	cases := []struct {
+		Namespace   string
+		MetricName  string
+		Aggregation string
+		Valid       bool
+	}{
+		{
+			Namespace:   "Microsoft.Storage/storageAccounts",
+			MetricName:  "SuccessE2ELatency",
+			Aggregation: "Average",
+			Valid:       true,
+		},
+		{
+			Namespace:   "Microsoft.Storage/storageAccounts",
+			MetricName:  "SuccessE2ELatency",
+			Aggregation: "Total",
+			Valid:       false,
+		},
+		{
+			Namespace:   "microsoft.compute/virtualmachines",
+			MetricName:  "percentage cpu",
+			Aggregation: "Maximum",
+			Valid:       true,
+		},
+		{
+			Namespace:   "Microsoft.Compute/virtualMachines",
+			MetricName:  "Percentage CPU",
+			Aggregation: "Total",
+			Valid:       false,
+		},
+		{
+			// unknown metrics are allowed
+			Namespace:   "Microsoft.Storage/storageAccounts",
+			MetricName:  "SomeNewMetric",
+			Aggregation: "Total",
+			Valid:       true,
+		},
+		{
+			// as are unknown namespaces
+			Namespace:   "Microsoft.Example/things",
+			MetricName:  "Transactions",
+			Aggregation: "Average",
+			Valid:       true,
+		},
+		{
+			// and values which aren't known yet
+			Namespace:   "Microsoft.Storage/storageAccounts",
+			MetricName:  "",
+			Aggregation: "Average",
+			Valid:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateMonitorMetricAlertAggregation(tc.Namespace, tc.MetricName, tc.Aggregation)
+		if tc.Valid && err != nil {
+			t.Fatalf("Expected %q / %q / %q to be valid but got: %+v", tc.Namespace, tc.MetricName, tc.Aggregation, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("Expected %q / %q / %q to be invalid but didn't get an error", tc.Namespace, tc.MetricName, tc.Aggregation)
+		}
+	}
+}
+
+func TestValidateMonitorMetricAlertWindowSize(t *testing.T) {
+	cases := []struct {
+		Frequency  string
+		WindowSize string
+		Valid      bool
+	}{
+		{
+			Frequency:  "PT1M",
+			WindowSize: "PT1M",
+			Valid:      true,
+		},
+		{
+			Frequency:  "PT1M",
+			WindowSize: "P1D",
+			Valid:      true,
+		},
+		{
+			Frequency:  "PT5M",
+			WindowSize: "PT1M",
+			Valid:      false,
+		},
+		{
+			Frequency:  "PT15M",
+			WindowSize: "PT5M",
+			Valid:      false,
+		},
+		{
+			Frequency:  "PT30M",
+			WindowSize: "PT12H",
+			Valid:      true,
+		},
+		{
+			Frequency:  "PT1H",
+			WindowSize: "PT30M",
+			Valid:      false,
+		},
+		{
+			Frequency:  "PT1H",
+			WindowSize: "PT6H",
+			Valid:      true,
+		},
+		{
+			// unknown frequencies are caught by the schema validation
+			Frequency:  "PT2H",
+			WindowSize: "PT1M",
+			Valid:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateMonitorMetricAlertWindowSize(tc.Frequency, tc.WindowSize)
+		if tc.Valid && err != nil {
+			t.Fatalf("Expected %q / %q to be valid but got: %+v", tc.Frequency, tc.WindowSize, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("Expected %q / %q to be invalid but didn't get an error", tc.Frequency, tc.WindowSize)
+		}
+	}
+}
+
+func TestValidateMonitorMetricAlertCriteriaNames(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Input       []interface{}
+		ShouldError bool
+	}{
+		{
+			Name:        "empty",
+			Input:       []interface{}{},
+			ShouldError: false,
+		},
+		{
+			Name: "unique names",
+			Input: []interface{}{
+				map[string]interface{}{"name": "criterion1"},
+				map[string]interface{}{"name": "criterion2"},
+				map[string]interface{}{"name": "criterion3"},
+			},
+			ShouldError: false,
+		},
+		{
+			Name: "default names",
+			Input: []interface{}{
+				map[string]interface{}{"name": ""},
+				map[string]interface{}{"name": ""},
+			},
+			ShouldError: false,
+		},
+		{
+			Name: "duplicate names",
+			Input: []interface{}{
+				map[string]interface{}{"name": "criterion1"},
+				map[string]interface{}{"name": "Criterion1"},
+			},
+			ShouldError: true,
+		},
+		{
+			Name: "duplicate of a default name",
+			Input: []interface{}{
+				map[string]interface{}{"name": ""},
+				map[string]interface{}{"name": "Metric1"},
+			},
+			ShouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := validateMonitorMetricAlertCriteriaNames(tc.Input)
+			if (err != nil) != tc.ShouldError {
+				t.Fatalf("Expected an error %t but got %+v", tc.ShouldError, err)
+			}
+		})
+	}
+}
+
+func TestSortMonitorMetricAlertCriteria(t *testing.T) {
+	existing := []interface{}{
+		map[string]interface{}{"name": "criterion3"},
+		map[string]interface{}{"name": "criterion1"},
+		map[string]interface{}{"name": "criterion2"},
+	}
+	input := []interface{}{
+		map[string]interface{}{"name": "criterion1"},
+		map[string]interface{}{"name": "unknown"},
+		map[string]interface{}{"name": "criterion2"},
+		map[string]interface{}{"name": "Criterion3"},
+	}
+
+	actual := sortMonitorMetricAlertCriteria(input, existing)
+	expected := []string{"Criterion3", "criterion1", "criterion2", "unknown"}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d criteria but got %d", len(expected), len(actual))
+	}
+	for i, name := range expected {
+		if actualName := actual[i].(map[string]interface{})["name"].(string); actualName != name {
+			t.Fatalf("Expected criteria %d to be %q but got %q", i, name, actualName)
+		}
+	}
+}
+
+func TestAccAzureRMMonitorMetricAlert_multipleCriteria(t *testing.T) {
+	resourceName := "azurerm_monitor_metric_alert.test"
+	ri := tf.AccRandTimeInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMMonitorMetricAlert_multipleCriteria(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMMonitorMetricAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMMonitorMetricAlertExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "criteria.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.name", "criterion1"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.0.metric_name", "Transactions"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.1.name", "criterion2"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.1.metric_name", "UsedCapacity"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.2.name", "criterion3"),
+					resource.TestCheckResourceAttr(resourceName, "criteria.2.metric_name", "Availability"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccAzureRMMonitorMetricAlert_requiresImport(t *testing.T) {
 	if !requireResourcesToBeImported {
 		t.Skip("Skipping since resources aren't required to be imported")
@@ -150,7 +768,7 @@ func TestAccAzureRMMonitorMetricAlert_basicAndCompleteUpdate(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMMonitorMetricAlertExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "false"),
+					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "true"),
 					resource.TestCheckResourceAttr(resourceName, "severity", "3"),
 					resource.TestCheckResourceAttr(resourceName, "description", ""),
 					resource.TestCheckResourceAttr(resourceName, "frequency", "PT1M"),
@@ -206,7 +824,7 @@ func TestAccAzureRMMonitorMetricAlert_basicAndCompleteUpdate(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMMonitorMetricAlertExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
-					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "false"),
+					resource.TestCheckResourceAttr(resourceName, "auto_mitigate", "true"),
 					resource.TestCheckResourceAttr(resourceName, "severity", "3"),
 					resource.TestCheckResourceAttr(resourceName, "description", ""),
 					resource.TestCheckResourceAttr(resourceName, "frequency", "PT1M"),
@@ -257,6 +875,140 @@ resource "azurerm_monitor_metric_alert" "test" {
 `, rInt, location, rString, rInt)
 }
 
+func testAccAzureRMMonitorMetricAlert_autoMitigate(rInt int, rString, location string, autoMitigate bool) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  scopes              = ["${azurerm_storage_account.test.id}"]
+  auto_mitigate       = %t
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThan"
+    threshold        = 55.5
+  }
+}
+`, rInt, location, rString, rInt, autoMitigate)
+}
+
+func testAccAzureRMMonitorMetricAlert_multipleScopes(rInt int, rString, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test1" {
+  name                     = "acctestsa1%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account" "test2" {
+  name                     = "acctestsa2%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                     = "acctestMetricAlert-%d"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  scopes                   = ["${azurerm_storage_account.test1.id}", "${azurerm_storage_account.test2.id}"]
+  target_resource_location = "${azurerm_resource_group.test.location}"
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "Transactions"
+    aggregation      = "Total"
+    operator         = "GreaterThan"
+    threshold        = 100
+  }
+}
+`, rInt, location, rString, rString, rInt)
+}
+
+// testAccAzureRMMonitorMetricAlert_multipleScopesDerivedLocation creates two pairs of Storage Accounts in different
+// locations, with the alert scoped to the pair named by `scope` and `target_resource_location` left to be looked up
+func testAccAzureRMMonitorMetricAlert_multipleScopesDerivedLocation(rInt int, rString, location, altLocation, scope string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test1" {
+  name     = "acctestRG1-%d"
+  location = "%s"
+}
+
+resource "azurerm_resource_group" "test2" {
+  name     = "acctestRG2-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test1a" {
+  name                     = "acctestsa1a%s"
+  resource_group_name      = "${azurerm_resource_group.test1.name}"
+  location                 = "${azurerm_resource_group.test1.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account" "test1b" {
+  name                     = "acctestsa1b%s"
+  resource_group_name      = "${azurerm_resource_group.test1.name}"
+  location                 = "${azurerm_resource_group.test1.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account" "test2a" {
+  name                     = "acctestsa2a%s"
+  resource_group_name      = "${azurerm_resource_group.test2.name}"
+  location                 = "${azurerm_resource_group.test2.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account" "test2b" {
+  name                     = "acctestsa2b%s"
+  resource_group_name      = "${azurerm_resource_group.test2.name}"
+  location                 = "${azurerm_resource_group.test2.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%d"
+  resource_group_name = "${azurerm_resource_group.test1.name}"
+  scopes              = ["${azurerm_storage_account.%sa.id}", "${azurerm_storage_account.%sb.id}"]
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "Transactions"
+    aggregation      = "Total"
+    operator         = "GreaterThan"
+    threshold        = 100
+  }
+}
+`, rInt, location, rInt, altLocation, rString, rString, rString, rString, rInt, scope, scope)
+}
+
 func testAccAzureRMMonitorMetricAlert_requiresImport(rInt int, rString, location string) string {
 	template := testAccAzureRMMonitorMetricAlert_basic(rInt, rString, location)
 	return fmt.Sprintf(`
@@ -278,6 +1030,56 @@ resource "azurerm_monitor_metric_alert" "import" {
 `, template)
 }
 
+func testAccAzureRMMonitorMetricAlert_multipleCriteria(rInt int, rString, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa1%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_monitor_metric_alert" "test" {
+  name                = "acctestMetricAlert-%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  scopes              = ["${azurerm_storage_account.test.id}"]
+
+  criteria {
+    name             = "criterion1"
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "Transactions"
+    aggregation      = "Total"
+    operator         = "GreaterThan"
+    threshold        = 100
+  }
+
+  criteria {
+    name             = "criterion2"
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThanOrEqual"
+    threshold        = 66.6
+  }
+
+  criteria {
+    name             = "criterion3"
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "Availability"
+    aggregation      = "Average"
+    operator         = "LessThan"
+    threshold        = 99
+  }
+}
+`, rInt, location, rString, rInt)
+}
+
 func testAccAzureRMMonitorMetricAlert_complete(rInt int, rString, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -26,6 +26,17 @@ func tagsForceNewSchema() *schema.Schema {
 	}
 }
 
+// tagsWithoutEmptyValuesSchema returns the tags schema for resources where Azure drops any tags with an empty value,
+// which would otherwise cause a perpetual diff
+func tagsWithoutEmptyValuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validateAzureRMTagsWithoutEmptyValues,
+	}
+}
+
 func tagsForDataSourceSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
@@ -47,8 +58,8 @@ func tagValueToString(v interface{}) (string, error) {
 func validateAzureRMTags(v interface{}, _ string) (warnings []string, errors []error) {
 	tagsMap := v.(map[string]interface{})
 
-	if len(tagsMap) > 15 {
-		errors = append(errors, fmt.Errorf("a maximum of 15 tags can be applied to each ARM resource"))
+	if len(tagsMap) > 50 {
+		errors = append(errors, fmt.Errorf("a maximum of 50 tags can be applied to each ARM resource"))
 	}
 
 	for k, v := range tagsMap {
@@ -67,6 +78,18 @@ func validateAzureRMTags(v interface{}, _ string) (warnings []string, errors []e
 	return warnings, errors
 }
 
+func validateAzureRMTagsWithoutEmptyValues(v interface{}, k string) (warnings []string, errors []error) {
+	warnings, errors = validateAzureRMTags(v, k)
+
+	for key, value := range v.(map[string]interface{}) {
+		if s, err := tagValueToString(value); err == nil && s == "" {
+			errors = append(errors, fmt.Errorf("the value for the tag %q cannot be empty, since Azure drops tags with empty values", key))
+		}
+	}
+
+	return warnings, errors
+}
+
 func expandTags(tagsMap map[string]interface{}) map[string]*string {
 	output := make(map[string]*string, len(tagsMap))
 
@@ -103,6 +126,31 @@ func filterTags(tagsMap map[string]*string, tagNames ...string) map[string]*stri
 	return tagsRet
 }
 
+// normalizeTagKeys renames any keys within the tag map which match one of the specified keys case-insensitively
+// to the casing of the specified key, since Azure doesn't always preserve the casing of tag keys
+func normalizeTagKeys(tagsMap map[string]*string, keys ...string) map[string]*string {
+	if len(keys) == 0 {
+		return tagsMap
+	}
+
+	keysDict := make(map[string]string)
+	for _, key := range keys {
+		keysDict[strings.ToLower(key)] = key
+	}
+
+	tagsRet := make(map[string]*string, len(tagsMap))
+	for k, v := range tagsMap {
+		if key, ok := keysDict[strings.ToLower(k)]; ok {
+			tagsRet[key] = v
+			continue
+		}
+
+		tagsRet[k] = v
+	}
+
+	return tagsRet
+}
+
 func flattenAndSetTags(d *schema.ResourceData, tagMap map[string]*string) {
 
 	// If tagsMap is nil, len(tagsMap) will be 0.
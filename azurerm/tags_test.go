@@ -8,7 +8,7 @@ import (
 
 func TestValidateMaximumNumberOfARMTags(t *testing.T) {
 	tagsMap := make(map[string]interface{})
-	for i := 0; i < 16; i++ {
+	for i := 0; i < 51; i++ {
 		tagsMap[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
 	}
 
@@ -18,11 +18,25 @@ func TestValidateMaximumNumberOfARMTags(t *testing.T) {
 		t.Fatal("Expected one validation error for too many tags")
 	}
 
-	if !strings.Contains(es[0].Error(), "a maximum of 15 tags") {
+	if !strings.Contains(es[0].Error(), "a maximum of 50 tags") {
 		t.Fatal("Wrong validation error message for too many tags")
 	}
 }
 
+func TestValidateARMTagsReturnsAllErrors(t *testing.T) {
+	tagsMap := make(map[string]interface{})
+	for i := 0; i < 49; i++ {
+		tagsMap[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	tagsMap[strings.Repeat("long", 128)+"a"] = "value"
+	tagsMap["toolong"] = strings.Repeat("long", 64) + "a"
+
+	_, es := validateAzureRMTags(tagsMap, "tags")
+	if len(es) != 3 {
+		t.Fatalf("Expected 3 validation errors (too many tags, a key which is too long and a value which is too long) but got %d: %+v", len(es), es)
+	}
+}
+
 func TestValidateARMTagMaxKeyLength(t *testing.T) {
 	tooLongKey := strings.Repeat("long", 128) + "a"
 	tagsMap := make(map[string]interface{})
@@ -68,6 +82,27 @@ func TestValidateARMTagMaxValueLength(t *testing.T) {
 	}
 }
 
+func TestValidateARMTagsWithoutEmptyValues(t *testing.T) {
+	tagsMap := map[string]interface{}{
+		"environment": "test",
+		"empty":       "",
+	}
+
+	_, es := validateAzureRMTagsWithoutEmptyValues(tagsMap, "tags")
+	if len(es) != 1 {
+		t.Fatalf("Expected one validation error for an empty value but got %d: %+v", len(es), es)
+	}
+
+	if !strings.Contains(es[0].Error(), `"empty"`) {
+		t.Fatal("Expected the validation error to contain the key name")
+	}
+
+	// tags with an empty value are fine when Azure keeps them
+	if _, es := validateAzureRMTags(tagsMap, "tags"); len(es) != 0 {
+		t.Fatalf("Expected no validation errors but got %+v", es)
+	}
+}
+
 func TestExpandARMTags(t *testing.T) {
 	testData := make(map[string]interface{})
 	testData["key1"] = "value1"
@@ -113,3 +148,30 @@ func TestFilterARMTags(t *testing.T) {
 		t.Fatalf("Expected %v in filtered tag map, got %v", valueData[1], *filtered["key2"])
 	}
 }
+
+func TestNormalizeARMTagKeys(t *testing.T) {
+	testData := make(map[string]*string)
+	valueData := [3]string{"value1", "value2", "value3"}
+
+	testData["ENVIRONMENT"] = &valueData[0]
+	testData["cost-Center"] = &valueData[1]
+	testData["owner"] = &valueData[2]
+
+	normalized := normalizeTagKeys(testData, "Environment", "cost-center", "")
+
+	if len(normalized) != 3 {
+		t.Fatalf("Expected 3 results in normalized tag map, got %d", len(normalized))
+	}
+
+	if normalized["Environment"] != &valueData[0] {
+		t.Fatalf("Expected %v for the key %q in normalized tag map, got %v", valueData[0], "Environment", normalized["Environment"])
+	}
+
+	if normalized["cost-center"] != &valueData[1] {
+		t.Fatalf("Expected %v for the key %q in normalized tag map, got %v", valueData[1], "cost-center", normalized["cost-center"])
+	}
+
+	if normalized["owner"] != &valueData[2] {
+		t.Fatalf("Expected %v for the key %q in normalized tag map, got %v", valueData[2], "owner", normalized["owner"])
+	}
+}
@@ -39,6 +39,30 @@ func TestResponseNotFound_StatusCodes(t *testing.T) {
 	}
 }
 
+func TestResponseForbidden_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		statusCode     int
+		expectedResult bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusForbidden, true},
+	}
+
+	for _, test := range testCases {
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: test.statusCode,
+			},
+		}
+		result := ResponseWasForbidden(resp)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
+				test.expectedResult, test.statusCode, result)
+		}
+	}
+}
+
 type testNetError struct {
 	timeout   bool
 	temporary bool